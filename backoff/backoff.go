@@ -0,0 +1,217 @@
+// Package backoff implements retry loops waiting between attempts with a pkg.Clock,
+// so tests using a mock clock control the exact retry times without sleeping.
+package backoff
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Policy creates the delays of retry loops.
+type Policy interface {
+	// Backoff returns the delays of a new retry loop.
+	Backoff() Backoff
+}
+
+// Backoff returns the successive delays between the attempts of a retry loop.
+type Backoff interface {
+	// Next returns the delay before the next attempt, given the time elapsed since the first attempt,
+	// or false to stop retrying.
+	Next(elapsed time.Duration) (time.Duration, bool)
+}
+
+// PolicyFunc adapts a function returning the delay before attempt n+1 after n failed attempts to a Policy.
+type PolicyFunc func(n int) time.Duration
+
+func (f PolicyFunc) Backoff() Backoff { return &funcBackoff{f: f} }
+
+type funcBackoff struct {
+	f func(n int) time.Duration
+	n int
+}
+
+func (b *funcBackoff) Next(time.Duration) (time.Duration, bool) {
+	b.n++
+
+	return b.f(b.n), true
+}
+
+// Constant waits d between attempts.
+func Constant(d time.Duration) Policy {
+	return PolicyFunc(func(int) time.Duration { return d })
+}
+
+// Linear waits initial after the first attempt, then increment more after each attempt.
+func Linear(initial, increment time.Duration) Policy {
+	return PolicyFunc(func(n int) time.Duration { return saturate(float64(initial) + float64(increment)*float64(n-1)) })
+}
+
+// Exponential waits initial after the first attempt, then multiplier times longer after each attempt,
+// up to limit. A limit less than or equal to zero is unlimited.
+func Exponential(initial time.Duration, multiplier float64, limit time.Duration) Policy {
+	return PolicyFunc(func(n int) time.Duration {
+		d := saturate(float64(initial) * math.Pow(multiplier, float64(n-1)))
+		if limit > 0 && d > limit {
+			return limit
+		}
+
+		return d
+	})
+}
+
+// DecorrelatedJitter waits a random delay between base and three times the previous delay, up to limit,
+// as described in https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/.
+// A limit less than or equal to zero is unlimited.
+// The random numbers are taken from src, or from the global source of math/rand/v2 if src is nil.
+// A seeded source makes the delays reproducible.
+func DecorrelatedJitter(base, limit time.Duration, src rand.Source) Policy {
+	return &jitterPolicy{base: base, limit: limit, rand: newRandom(src)}
+}
+
+type jitterPolicy struct {
+	base, limit time.Duration
+	rand        *random
+}
+
+func (p *jitterPolicy) Backoff() Backoff { return &jitterBackoff{policy: p, prev: p.base} }
+
+type jitterBackoff struct {
+	policy *jitterPolicy
+	prev   time.Duration
+}
+
+func (b *jitterBackoff) Next(time.Duration) (time.Duration, bool) {
+	p := b.policy
+	hi := saturate(float64(b.prev) * 3) //nolint:gomnd
+
+	d := p.base
+	if hi > p.base {
+		d += p.rand.durationN(hi - p.base)
+	}
+
+	if p.limit > 0 && d > p.limit {
+		d = p.limit
+	}
+
+	b.prev = d
+
+	return d, true
+}
+
+// WithJitter randomizes the delays of the policy by up to fraction of their value in both directions,
+// so a fraction of 0.5 turns a delay d into a random delay between d/2 and 3d/2.
+// The fraction is clamped to [0, 1], so the delays are never negative.
+// The random numbers are taken from src, or from the global source of math/rand/v2 if src is nil.
+func WithJitter(p Policy, fraction float64, src rand.Source) Policy {
+	r := newRandom(src)
+	fraction = min(max(fraction, 0), 1)
+
+	return wrap(p, func(next nextFunc, elapsed time.Duration) (time.Duration, bool) {
+		d, ok := next(elapsed)
+		if !ok {
+			return 0, false
+		}
+
+		spread := saturate(float64(d) * fraction * 2) //nolint:gomnd
+		if spread <= 0 {
+			return d, true
+		}
+
+		// Include spread itself in the random range, unless it is saturated.
+		n := spread
+		if n < math.MaxInt64 {
+			n++
+		}
+
+		return saturate(float64(d) - float64(spread)/2 + float64(r.durationN(n))), true
+	})
+}
+
+// WithMaxAttempts stops retrying after n attempts, including the first one.
+func WithMaxAttempts(p Policy, n int) Policy {
+	return policyFunc(func() Backoff {
+		b, attempts := p.Backoff(), 1
+
+		return backoffFunc(func(elapsed time.Duration) (time.Duration, bool) {
+			if attempts >= n {
+				return 0, false
+			}
+
+			attempts++
+
+			return b.Next(elapsed)
+		})
+	})
+}
+
+// WithMaxElapsed stops retrying when the next attempt would start more than maxElapsed after the first one.
+func WithMaxElapsed(p Policy, maxElapsed time.Duration) Policy {
+	return wrap(p, func(next nextFunc, elapsed time.Duration) (time.Duration, bool) {
+		// elapsed+d would overflow with a saturated delay.
+		d, ok := next(elapsed)
+		if !ok || d > maxElapsed-elapsed {
+			return 0, false
+		}
+
+		return d, true
+	})
+}
+
+type nextFunc func(elapsed time.Duration) (time.Duration, bool)
+
+// wrap returns a policy whose backoffs call f with the Next method of the backoffs of p.
+func wrap(p Policy, f func(next nextFunc, elapsed time.Duration) (time.Duration, bool)) Policy {
+	return policyFunc(func() Backoff {
+		b := p.Backoff()
+
+		return backoffFunc(func(elapsed time.Duration) (time.Duration, bool) { return f(b.Next, elapsed) })
+	})
+}
+
+type policyFunc func() Backoff
+
+func (f policyFunc) Backoff() Backoff { return f() }
+
+type backoffFunc nextFunc
+
+func (f backoffFunc) Next(elapsed time.Duration) (time.Duration, bool) { return f(elapsed) }
+
+// random is a source of random durations safe for concurrent use.
+type random struct {
+	mu   sync.Mutex
+	rand *rand.Rand // nil for the global source
+}
+
+func newRandom(src rand.Source) *random {
+	if src == nil {
+		return &random{}
+	}
+
+	return &random{rand: rand.New(src)} //nolint:gosec // jitter does not need a cryptographic source
+}
+
+// durationN returns a random duration in [0, n).
+func (r *random) durationN(n time.Duration) time.Duration {
+	if r.rand == nil {
+		return rand.N(n) //nolint:gosec
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return time.Duration(r.rand.Int64N(int64(n)))
+}
+
+// saturate converts a number of nanoseconds to a duration, saturating at the bounds of time.Duration.
+func saturate(ns float64) time.Duration {
+	switch {
+	case ns >= math.MaxInt64:
+		return math.MaxInt64
+	case ns <= math.MinInt64:
+		return math.MinInt64
+	default:
+		return time.Duration(ns)
+	}
+}
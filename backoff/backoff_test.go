@@ -0,0 +1,148 @@
+package backoff_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/backoff"
+)
+
+// delays returns the first n delays of a new backoff of the policy, with no time elapsed.
+func delays(p backoff.Policy, n int) []time.Duration {
+	b := p.Backoff()
+	out := make([]time.Duration, 0, n)
+
+	for range n {
+		d, ok := b.Next(0)
+		if !ok {
+			break
+		}
+
+		out = append(out, d)
+	}
+
+	return out
+}
+
+func expectDelays(t *testing.T, got []time.Duration, want ...time.Duration) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("delays = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("delays = %v, want %v", got, want)
+		}
+	}
+}
+
+// Ensure that the deterministic policies return the expected delays.
+func TestPolicies(t *testing.T) {
+	expectDelays(t, delays(backoff.Constant(time.Second), 3), time.Second, time.Second, time.Second)
+	expectDelays(t, delays(backoff.Linear(time.Second, 2*time.Second), 3), time.Second, 3*time.Second, 5*time.Second)
+	expectDelays(t, delays(backoff.Exponential(time.Second, 2, 5*time.Second), 4),
+		time.Second, 2*time.Second, 4*time.Second, 5*time.Second)
+
+	// The delays saturate instead of overflowing.
+	if d := delays(backoff.Exponential(time.Hour, 10, 0), 20)[19]; d != math.MaxInt64 {
+		t.Fatalf("delay = %v, want %v", d, time.Duration(math.MaxInt64))
+	}
+}
+
+// Ensure that WithMaxElapsed stops retrying when a saturated delay would overflow the elapsed time.
+func TestWithMaxElapsed_Saturated(t *testing.T) {
+	b := backoff.WithMaxElapsed(backoff.Exponential(time.Hour, 10, 0), 365*24*time.Hour).Backoff()
+
+	for _, elapsed := range []time.Duration{0, time.Hour, 10 * time.Hour} {
+		if _, ok := b.Next(elapsed); !ok {
+			t.Fatalf("Next(%v) stopped, want a delay", elapsed)
+		}
+	}
+
+	// The uncapped delays saturate at math.MaxInt64 after 19 attempts.
+	for range 20 {
+		b.Next(0)
+	}
+
+	if d, ok := b.Next(time.Minute); ok {
+		t.Fatalf("Next(1m) = %v, want to stop", d)
+	}
+}
+
+// Ensure that WithMaxAttempts counts the first attempt.
+func TestWithMaxAttempts(t *testing.T) {
+	expectDelays(t, delays(backoff.WithMaxAttempts(backoff.Constant(time.Second), 3), 10), time.Second, time.Second)
+	expectDelays(t, delays(backoff.WithMaxAttempts(backoff.Constant(time.Second), 1), 10))
+}
+
+// Ensure that the decorrelated jitter stays in its bounds and is reproducible with a seeded source.
+func TestDecorrelatedJitter(t *testing.T) {
+	const base, max = 100 * time.Millisecond, 10 * time.Second
+
+	got := delays(backoff.DecorrelatedJitter(base, max, rand.NewPCG(1, 2)), 50)
+	again := delays(backoff.DecorrelatedJitter(base, max, rand.NewPCG(1, 2)), 50)
+
+	prev := base
+	for i, d := range got {
+		if d < base || d > max || d > 3*prev {
+			t.Fatalf("delay %d = %v after %v, want in [%v, %v]", i, d, prev, base, min(max, 3*prev))
+		}
+
+		if again[i] != d {
+			t.Fatalf("delay %d = %v and %v with the same seed", i, d, again[i])
+		}
+
+		prev = d
+	}
+
+	if got[len(got)-1] == got[len(got)-2] && got[len(got)-1] != max {
+		t.Fatalf("delays = %v, want random delays", got)
+	}
+}
+
+// Ensure that WithJitter randomizes the delays by at most the fraction.
+func TestWithJitter(t *testing.T) {
+	p := backoff.WithJitter(backoff.Constant(time.Second), 0.25, rand.NewPCG(3, 4))
+
+	distinct := map[time.Duration]bool{}
+
+	for _, d := range delays(p, 100) {
+		if d < 750*time.Millisecond || d > 1250*time.Millisecond {
+			t.Fatalf("delay = %v, want in [750ms, 1.25s]", d)
+		}
+
+		distinct[d] = true
+	}
+
+	if len(distinct) < 50 {
+		t.Fatalf("%d distinct delays, want random delays", len(distinct))
+	}
+
+	expectDelays(t, delays(backoff.WithJitter(backoff.Constant(time.Second), 0, nil), 2), time.Second, time.Second)
+}
+
+// Ensure that WithJitter clamps the fraction, so the delays are never negative.
+func TestWithJitter_Fraction(t *testing.T) {
+	for _, d := range delays(backoff.WithJitter(backoff.Constant(time.Second), 3, rand.NewPCG(5, 6)), 100) {
+		if d < 0 || d > 2*time.Second {
+			t.Fatalf("delay = %v, want in [0s, 2s]", d)
+		}
+	}
+
+	expectDelays(t, delays(backoff.WithJitter(backoff.Constant(time.Second), -1, nil), 2), time.Second, time.Second)
+}
+
+// Ensure that WithJitter does not overflow when the delays saturate.
+func TestWithJitter_Saturated(t *testing.T) {
+	p := backoff.WithJitter(backoff.Exponential(time.Hour, 10, 0), 1, rand.NewPCG(7, 8))
+
+	for i, d := range delays(p, 30) {
+		if d < 0 {
+			t.Fatalf("delay %d = %v, want non-negative", i, d)
+		}
+	}
+}
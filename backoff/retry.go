@@ -0,0 +1,70 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// PermanentError stops a retry loop.
+type PermanentError struct {
+	Err error
+}
+
+// Permanent wraps err to stop a retry loop, which returns err.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &PermanentError{Err: err}
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Retry calls fn until it succeeds, waiting with the clock for the delays of the policy between attempts.
+//
+// It returns nil once fn succeeds. It returns the error of the last attempt if the policy stops retrying,
+// or the error wrapped by Permanent if fn returns one. If the context is done first, it returns the cause
+// of the context, wrapping the error of the last attempt if any.
+func Retry(ctx context.Context, clock pkg.Clock, policy Policy, fn func(ctx context.Context) error) error {
+	var (
+		b     = policy.Backoff()
+		start = clock.Now()
+	)
+
+	for {
+		if ctx.Err() != nil {
+			return context.Cause(ctx) //nolint:wrapcheck
+		}
+
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *PermanentError
+		if errors.As(err, &permanent) {
+			return permanent.Err
+		}
+
+		delay, ok := b.Next(clock.Since(start))
+		if !ok {
+			return err
+		}
+
+		timer := clock.Timer(delay)
+
+		select {
+		case <-timer.Chan():
+		case <-ctx.Done():
+			timer.Stop()
+
+			return fmt.Errorf("%w (last error: %w)", context.Cause(ctx), err)
+		}
+	}
+}
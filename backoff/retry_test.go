@@ -0,0 +1,132 @@
+package backoff_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/backoff"
+	"github.com/itbasis/go-clock/v2/clocktest"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+var errTransient = errors.New("transient")
+
+// retry runs Retry on the mock clock, stepping to each wait, and returns the times of the attempts
+// relative to the start.
+func retry(
+	ctx context.Context, mock pkg.Mock, policy backoff.Policy, fn func(ctx context.Context, attempt int) error,
+) ([]time.Duration, error) {
+	var (
+		start    = mock.Now()
+		attempts []time.Duration
+		err      error
+	)
+
+	stepping, stop := context.WithCancel(context.Background())
+
+	go func() {
+		defer stop()
+
+		err = backoff.Retry(ctx, mock, policy, func(ctx context.Context) error {
+			attempts = append(attempts, mock.Since(start))
+
+			return fn(ctx, len(attempts))
+		})
+	}()
+
+	for mock.BlockUntilContext(stepping, 1) == nil {
+		mock.Step()
+	}
+
+	<-stepping.Done()
+
+	return attempts, err
+}
+
+// Ensure that Retry waits for the exponential delays until the last attempt.
+func TestRetry_Exponential(t *testing.T) {
+	mock := clocktest.New(t)
+	policy := backoff.WithMaxAttempts(backoff.Exponential(time.Second, 2, 5*time.Second), 5)
+
+	attempts, err := retry(context.Background(), mock, policy, func(context.Context, int) error { return errTransient })
+	if !errors.Is(err, errTransient) {
+		t.Fatalf("Retry() error = %v, want %v", err, errTransient)
+	}
+
+	expectDelays(t, attempts, 0, time.Second, 3*time.Second, 7*time.Second, 12*time.Second)
+}
+
+// Ensure that Retry stops before an attempt due after the maximum elapsed time.
+func TestRetry_MaxElapsed(t *testing.T) {
+	mock := clocktest.New(t)
+	policy := backoff.WithMaxElapsed(backoff.Linear(time.Second, time.Second), 7*time.Second)
+
+	attempts, err := retry(context.Background(), mock, policy, func(context.Context, int) error { return errTransient })
+	if !errors.Is(err, errTransient) {
+		t.Fatalf("Retry() error = %v, want %v", err, errTransient)
+	}
+
+	expectDelays(t, attempts, 0, time.Second, 3*time.Second, 6*time.Second)
+}
+
+// Ensure that Retry returns once an attempt succeeds.
+func TestRetry_Success(t *testing.T) {
+	mock := clocktest.New(t)
+
+	attempts, err := retry(context.Background(), mock, backoff.Constant(time.Second), func(_ context.Context, n int) error {
+		if n < 3 {
+			return errTransient
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Retry() error = %v", err)
+	}
+
+	expectDelays(t, attempts, 0, time.Second, 2*time.Second)
+}
+
+// Ensure that Retry stops on a permanent error.
+func TestRetry_Permanent(t *testing.T) {
+	mock := clocktest.New(t)
+	errFatal := errors.New("fatal")
+
+	attempts, err := retry(context.Background(), mock, backoff.Constant(time.Second), func(_ context.Context, n int) error {
+		if n < 2 {
+			return errTransient
+		}
+
+		return backoff.Permanent(errFatal)
+	})
+	if err != errFatal { //nolint:errorlint
+		t.Fatalf("Retry() error = %v, want %v", err, errFatal)
+	}
+
+	expectDelays(t, attempts, 0, time.Second)
+}
+
+// Ensure that Retry stops waiting when the context is done.
+func TestRetry_Context(t *testing.T) {
+	mock := clocktest.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts, err := retry(ctx, mock, backoff.Constant(time.Second), func(_ context.Context, n int) error {
+		if n == 2 {
+			cancel()
+		}
+
+		return errTransient
+	})
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errTransient) {
+		t.Fatalf("Retry() error = %v, want the context error and the last error", err)
+	}
+
+	expectDelays(t, attempts, 0, time.Second)
+
+	if _, err := retry(ctx, mock, backoff.Constant(time.Second), nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Retry() error = %v, want %v", err, context.Canceled)
+	}
+}
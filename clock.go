@@ -1,18 +1,95 @@
 package clock
 
 import (
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/frozen"
 	"github.com/itbasis/go-clock/v2/internal/impl"
 	"github.com/itbasis/go-clock/v2/internal/mock"
+	"github.com/itbasis/go-clock/v2/internal/offset"
+	"github.com/itbasis/go-clock/v2/internal/scaled"
+	"github.com/itbasis/go-clock/v2/internal/stopwatch"
 	"github.com/itbasis/go-clock/v2/pkg"
 )
 
+// MockOption configures a mock clock on initialization.
+type MockOption = mock.Option
+
 // New returns an instance of a real-time clock.
 func New() pkg.Clock {
 	return impl.NewClock()
 }
 
+// NewFreezable returns a clock following the base clock, which can be frozen and unfrozen at runtime.
+func NewFreezable(base pkg.Clock) pkg.Freezable {
+	return frozen.NewClock(base)
+}
+
+// NewFrozen returns a real-time clock frozen at t. Unfreezing it resumes the time from t.
+func NewFrozen(t time.Time) pkg.Freezable {
+	return frozen.NewFrozenClock(impl.NewClock(), t)
+}
+
+// NewOffset returns a clock reporting the time of the base clock shifted by the offset,
+// to run code as if it was another date. The durations of timers, tickers and sleeps are unchanged.
+func NewOffset(base pkg.Clock, d time.Duration) pkg.OffsetClock {
+	return offset.NewClock(base, d)
+}
+
+// NewScaled returns a clock reporting a virtual time which starts at epoch and moves factor times
+// as fast as the base clock. Timers, tickers, sleeps and contexts wait for the durations of the base clock
+// scaled accordingly. The factor must be greater than zero; if not, NewScaled will panic.
+func NewScaled(base pkg.Clock, factor float64, epoch time.Time) pkg.Clock {
+	return scaled.NewClock(base, factor, epoch)
+}
+
+// NewStopwatch returns a stopped stopwatch measuring the time elapsed on the clock while it is running.
+func NewStopwatch(c pkg.Clock) pkg.Stopwatch {
+	return stopwatch.NewStopwatch(c)
+}
+
 // NewMock returns an instance of a mock clock.
-// The current time of the mock clock on initialization is the Unix epoch.
-func NewMock() pkg.Mock {
-	return mock.NewMock()
+// The current time of the mock clock on initialization is the Unix epoch, unless set by options.
+func NewMock(opts ...MockOption) pkg.Mock {
+	return mock.NewMock(opts...)
+}
+
+// NewMockAt returns an instance of a mock clock with the current time set to t.
+func NewMockAt(t time.Time, opts ...MockOption) pkg.Mock {
+	return mock.NewMockAt(t, opts...)
+}
+
+// WithStartTime sets the current time of the mock clock on initialization.
+func WithStartTime(t time.Time) MockOption { return mock.WithStartTime(t) }
+
+// WithLocation sets the location of the times reported by the mock clock.
+func WithLocation(loc *time.Location) MockOption { return mock.WithLocation(loc) }
+
+// WithMonotonic sets the monotonic clock reading of the mock clock on initialization.
+func WithMonotonic(d time.Duration) MockOption { return mock.WithMonotonic(d) }
+
+// WithBackwardJump sets the policy for the pending timers when Set moves the time of the mock clock backward.
+func WithBackwardJump(policy pkg.BackwardJump) MockOption { return mock.WithBackwardJump(policy) }
+
+// WithSyncAfterFunc makes the mock clock execute the functions passed to AfterFunc
+// in the goroutine moving the clock, so they are done by the time Add or Set returns.
+func WithSyncAfterFunc() MockOption { return mock.WithSyncAfterFunc() }
+
+// WithSettleTimeout sets the real time RunUntilIdle and WaitForAllTimers wait for the functions
+// passed to AfterFunc to return or wait on the clock.
+func WithSettleTimeout(d time.Duration) MockOption { return mock.WithSettleTimeout(d) }
+
+// WithStackDepth sets the number of stack frames captured when a timer of the mock clock is created,
+// reported by PendingTimers. A depth of 0 captures nothing.
+func WithStackDepth(n int) MockOption { return mock.WithStackDepth(n) }
+
+// WithTickerPolicy sets the policy of the tickers of the mock clock for ticks due before the previous one is received.
+func WithTickerPolicy(policy pkg.TickerPolicy) MockOption { return mock.WithTickerPolicy(policy) }
+
+// SetLabel labels a timer or a ticker of a mock clock, so it can be identified in snapshots
+// returned by pkg.Mock.PendingTimers. It does nothing for other implementations.
+func SetLabel(t any, label string) {
+	if l, ok := t.(pkg.Labeler); ok {
+		l.SetLabel(label)
+	}
 }
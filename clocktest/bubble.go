@@ -0,0 +1,18 @@
+//go:build go1.25
+
+// The build constraint keeps the module usable with the Go version of go.mod, before testing/synctest
+// was released, and sets the language version of this file to Go 1.25.
+
+package clocktest
+
+import (
+	"github.com/itbasis/go-clock/v2/internal/bubble"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// NewBubble returns a clock following the fake time of the testing/synctest bubble of the calling goroutine.
+// Code using this clock and code using the time package share the same fake time.
+// It lives in this package so that the clock package does not depend on testing packages.
+func NewBubble() pkg.Bubble {
+	return bubble.NewClock()
+}
@@ -0,0 +1,44 @@
+package clocktest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/itbasis/go-clock/v2"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// New returns a mock clock for the test. At the end of the test, it fails the test if timers,
+// tickers, sleeps or context deadlines are still pending on the clock, reporting where each one was created.
+// Timers stopped by the cleanup functions registered after New are not reported.
+func New(tb testing.TB, opts ...clock.MockOption) pkg.Mock {
+	tb.Helper()
+
+	m := clock.NewMock(opts...)
+
+	tb.Cleanup(func() {
+		if pending := m.PendingTimers(); len(pending) > 0 {
+			tb.Error(leakReport(pending))
+		}
+	})
+
+	return m
+}
+
+// leakReport describes the timers pending at the end of a test.
+func leakReport(pending []pkg.TimerInfo) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d timers still pending on the mock clock at the end of the test:", len(pending))
+
+	for _, p := range pending {
+		fmt.Fprintf(&b, "\n\t%s", p)
+
+		if p.Stack != "" {
+			fmt.Fprintf(&b, "\n\t\t%s", strings.ReplaceAll(strings.TrimSpace(p.Stack), "\n", "\n\t\t"))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package clocktest_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/clocktest"
+)
+
+// recorder records the errors and runs the cleanups of a test.
+type recorder struct {
+	testing.TB
+
+	errors   []string
+	cleanups []func()
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Error(args ...any) { r.errors = append(r.errors, fmt.Sprint(args...)) }
+
+func (r *recorder) Cleanup(f func()) { r.cleanups = append(r.cleanups, f) }
+
+// finish runs the cleanups in reverse order, like the testing package at the end of a test.
+func (r *recorder) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+// Ensure that the clock does not fail the test if nothing is pending at the end.
+func TestNew(t *testing.T) {
+	r := &recorder{TB: t}
+	m := clocktest.New(r)
+
+	timer := m.Timer(time.Second)
+	ticker := m.Ticker(time.Second)
+
+	_, cancel := m.WithTimeout(context.Background(), time.Second)
+
+	r.Cleanup(ticker.Stop)
+	r.Cleanup(cancel)
+
+	m.Add(time.Second)
+	<-timer.Chan()
+
+	r.finish()
+
+	if len(r.errors) != 0 {
+		t.Fatalf("errors = %q, want none", r.errors)
+	}
+}
+
+// Ensure that the clock fails the test with the location of the leaked timers.
+func TestNew_Leak(t *testing.T) {
+	r := &recorder{TB: t}
+	m := clocktest.New(r)
+
+	m.Timer(time.Second)
+	m.Ticker(time.Minute)
+
+	_, cancel := m.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	r.finish()
+
+	if len(r.errors) != 1 {
+		t.Fatalf("errors = %q, want one", r.errors)
+	}
+
+	report := r.errors[0]
+
+	for _, want := range []string{"3 timers still pending", "timer at", "ticker at", "context deadline at", "clocktest_test.go:"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report does not contain %q:\n%s", want, report)
+		}
+	}
+}
@@ -0,0 +1,483 @@
+// Package clocktest provides utilities for testing implementations and users of pkg.Clock.
+package clocktest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+const (
+	// Short is the duration of the timers expected to fire during a conformance check.
+	Short = 20 * time.Millisecond
+	// Long is the duration of the timers not expected to fire during a conformance check.
+	Long = time.Hour
+
+	// waitTimeout is the real time to wait for an event expected to happen.
+	waitTimeout = time.Second
+)
+
+// Subject is a clock checked by RunConformance.
+type Subject struct {
+	Clock pkg.Clock
+
+	// Advance moves the clock forward by the duration.
+	Advance func(d time.Duration)
+
+	// BlockUntil blocks until n timers, tickers and sleeps are registered on the clock.
+	// It is optional for clocks which are not moved forward programmatically.
+	BlockUntil func(n int)
+}
+
+// Factory returns a new subject for each conformance check.
+type Factory func(t *testing.T) Subject
+
+// RealTime returns a subject for a clock following the real time.
+// Advance sleeps for the duration, plus a margin for the timers to fire.
+func RealTime(c pkg.Clock) Subject {
+	return Subject{
+		Clock:   c,
+		Advance: func(d time.Duration) { time.Sleep(d + Short/2) },
+	}
+}
+
+// Mock returns a subject for a mock clock.
+func Mock(m pkg.Mock) Subject {
+	return Subject{Clock: m, Advance: m.Add, BlockUntil: m.BlockUntil}
+}
+
+type check struct {
+	name string
+	run  func(t *testing.T, s Subject)
+}
+
+// RunConformance checks that the clocks returned by the factory follow the contract of pkg.Clock,
+// pkg.Timer and pkg.Ticker, which is the one of the standard library time package since Go 1.23.
+func RunConformance(t *testing.T, factory Factory) {
+	t.Helper()
+
+	for _, checks := range [][]check{clockChecks, timerChecks, tickerChecks, contextChecks} {
+		for _, c := range checks {
+			t.Run(
+				c.name, func(t *testing.T) {
+					t.Parallel()
+
+					c.run(t, factory(t))
+				},
+			)
+		}
+	}
+}
+
+var clockChecks = []check{
+	{
+		name: "Now/Since/Until",
+		run: func(t *testing.T, s Subject) {
+			before := s.Clock.Now()
+			s.Advance(Short)
+			after := s.Clock.Now()
+
+			if d := after.Sub(before); d < Short {
+				t.Errorf("Now() moved by %v, want at least %v", d, Short)
+			}
+
+			if d := s.Clock.Since(before); d < Short {
+				t.Errorf("Since() = %v, want at least %v", d, Short)
+			}
+
+			if d := s.Clock.Until(after.Add(Long)); d <= 0 || d > Long {
+				t.Errorf("Until() = %v, want in (0, %v]", d, Long)
+			}
+		},
+	},
+	{
+		name: "After",
+		run: func(t *testing.T, s Subject) {
+			ch := s.Clock.After(Short)
+			expectNotFired(t, ch)
+			s.Advance(Short)
+			expectFired(t, ch)
+		},
+	},
+	{
+		name: "AfterFunc",
+		run: func(t *testing.T, s Subject) {
+			fired := make(chan time.Time, 1)
+			s.Clock.AfterFunc(Short, func() { fired <- time.Now() })
+			expectNotFired(t, fired)
+			s.Advance(Short)
+			expectFired(t, fired)
+		},
+	},
+	{
+		name: "AfterFunc stop before expiry",
+		run: func(t *testing.T, s Subject) {
+			timer := s.Clock.AfterFunc(Short, func() { t.Error("unexpected function execution") })
+
+			if !timer.Stop() {
+				t.Error("Stop() = false, want true")
+			}
+
+			s.Advance(Short)
+		},
+	},
+	{
+		name: "AfterFunc stop after expiry",
+		run: func(t *testing.T, s Subject) {
+			fired := make(chan time.Time, 1)
+			timer := s.Clock.AfterFunc(Short, func() { fired <- time.Now() })
+			s.Advance(Short)
+			expectFired(t, fired)
+
+			if timer.Stop() {
+				t.Error("Stop() = true, want false")
+			}
+
+			if timer.Reset(Long) {
+				t.Error("Reset() = true, want false")
+			}
+
+			if !timer.Stop() {
+				t.Error("Stop() = false, want true")
+			}
+		},
+	},
+	{
+		name: "Sleep",
+		run: func(t *testing.T, s Subject) {
+			done := make(chan struct{})
+
+			go func() {
+				s.Clock.Sleep(Short)
+				close(done)
+			}()
+
+			blockUntil(s, 1)
+			s.Advance(Short)
+			expectFired(t, done)
+		},
+	},
+	{
+		name: "Tick",
+		run: func(t *testing.T, s Subject) {
+			tick := s.Clock.Tick(Short)
+
+			for i := 0; i < 2; i++ {
+				s.Advance(Short)
+				expectFired(t, tick)
+			}
+		},
+	},
+}
+
+var timerChecks = []check{
+	{
+		name: "Timer",
+		run: func(t *testing.T, s Subject) {
+			timer := s.Clock.Timer(Short)
+			expectNotFired(t, timer.Chan())
+			s.Advance(Short)
+			expectFired(t, timer.Chan())
+		},
+	},
+	{
+		name: "Timer stop before expiry",
+		run: func(t *testing.T, s Subject) {
+			timer := s.Clock.Timer(Long)
+
+			if !timer.Stop() {
+				t.Error("Stop() = false, want true")
+			}
+
+			if timer.Stop() {
+				t.Error("second Stop() = true, want false")
+			}
+
+			expectNotFired(t, timer.Chan())
+		},
+	},
+	{
+		name: "Timer stop after expiry, value not received",
+		run: func(t *testing.T, s Subject) {
+			timer := s.Clock.Timer(Short)
+			s.Advance(Short)
+
+			if !timer.Stop() {
+				t.Error("Stop() = false, want true")
+			}
+
+			expectNotFired(t, timer.Chan())
+		},
+	},
+	{
+		name: "Timer stop after value received",
+		run: func(t *testing.T, s Subject) {
+			timer := s.Clock.Timer(Short)
+			s.Advance(Short)
+			expectFired(t, timer.Chan())
+
+			if timer.Stop() {
+				t.Error("Stop() = true, want false")
+			}
+		},
+	},
+	{
+		name: "Timer reset before expiry",
+		run: func(t *testing.T, s Subject) {
+			timer := s.Clock.Timer(Long)
+
+			if !timer.Reset(Short) {
+				t.Error("Reset() = false, want true")
+			}
+
+			s.Advance(Short)
+			expectFired(t, timer.Chan())
+			expectNotFired(t, timer.Chan())
+		},
+	},
+	{
+		name: "Timer reset after expiry, value not received",
+		run: func(t *testing.T, s Subject) {
+			timer := s.Clock.Timer(Short)
+			s.Advance(Short)
+
+			if !timer.Reset(Long) {
+				t.Error("Reset() = false, want true")
+			}
+
+			expectNotFired(t, timer.Chan())
+		},
+	},
+	{
+		name: "Timer reset after value received",
+		run: func(t *testing.T, s Subject) {
+			timer := s.Clock.Timer(Short)
+			s.Advance(Short)
+			expectFired(t, timer.Chan())
+
+			if timer.Reset(Short) {
+				t.Error("Reset() = true, want false")
+			}
+
+			s.Advance(Short)
+			expectFired(t, timer.Chan())
+		},
+	},
+	{
+		name: "Timer negative duration",
+		run: func(t *testing.T, s Subject) {
+			timer := s.Clock.Timer(-time.Second)
+			expectFired(t, timer.Chan())
+		},
+	},
+}
+
+var tickerChecks = []check{
+	{
+		name: "Ticker",
+		run: func(t *testing.T, s Subject) {
+			ticker := s.Clock.Ticker(Short)
+			defer ticker.Stop()
+
+			expectNotFired(t, ticker.Chan())
+
+			for i := 0; i < 2; i++ {
+				s.Advance(Short)
+				expectFired(t, ticker.Chan())
+			}
+		},
+	},
+	{
+		name: "Ticker stop, tick not received",
+		run: func(t *testing.T, s Subject) {
+			ticker := s.Clock.Ticker(Short)
+			s.Advance(Short)
+			ticker.Stop()
+			expectNotFired(t, ticker.Chan())
+			s.Advance(Short)
+			expectNotFired(t, ticker.Chan())
+		},
+	},
+	{
+		name: "Ticker reset, tick not received",
+		run: func(t *testing.T, s Subject) {
+			ticker := s.Clock.Ticker(Short)
+			defer ticker.Stop()
+
+			s.Advance(Short)
+			ticker.Reset(Long)
+			expectNotFired(t, ticker.Chan())
+		},
+	},
+	{
+		name: "Ticker reset after stop",
+		run: func(t *testing.T, s Subject) {
+			ticker := s.Clock.Ticker(Long)
+			defer ticker.Stop()
+
+			ticker.Stop()
+			ticker.Reset(Short)
+			s.Advance(Short)
+			expectFired(t, ticker.Chan())
+		},
+	},
+	{
+		name: "Ticker non-positive interval",
+		run: func(t *testing.T, s Subject) {
+			expectPanic(t, "non-positive interval for NewTicker", func() { s.Clock.Ticker(0) })
+
+			ticker := s.Clock.Ticker(Long)
+			defer ticker.Stop()
+
+			expectPanic(t, "non-positive interval for Ticker.Reset", func() { ticker.Reset(-time.Second) })
+		},
+	},
+}
+
+var contextChecks = []check{
+	{
+		name: "WithDeadline",
+		run: func(t *testing.T, s Subject) {
+			deadline := s.Clock.Now().Add(Short)
+
+			ctx, cancel := s.Clock.WithDeadline(context.Background(), deadline)
+			defer cancel()
+
+			if d, ok := ctx.Deadline(); !ok || !d.Equal(deadline) {
+				t.Errorf("Deadline() = %v, %v, want %v, true", d, ok, deadline)
+			}
+
+			expectNotDone(t, ctx)
+			s.Advance(Short)
+			expectDone(t, ctx, context.DeadlineExceeded)
+		},
+	},
+	{
+		name: "WithDeadline cancel",
+		run: func(t *testing.T, s Subject) {
+			ctx, cancel := s.Clock.WithDeadline(context.Background(), s.Clock.Now().Add(Long))
+			cancel()
+			expectDone(t, ctx, context.Canceled)
+		},
+	},
+	{
+		name: "WithDeadline passed",
+		run: func(t *testing.T, s Subject) {
+			ctx, cancel := s.Clock.WithDeadline(context.Background(), s.Clock.Now().Add(-time.Second))
+			defer cancel()
+
+			expectDone(t, ctx, context.DeadlineExceeded)
+		},
+	},
+	{
+		name: "WithDeadline parent canceled",
+		run: func(t *testing.T, s Subject) {
+			parent, cancelParent := context.WithCancel(context.Background())
+
+			ctx, cancel := s.Clock.WithDeadline(parent, s.Clock.Now().Add(Long))
+			defer cancel()
+
+			cancelParent()
+			expectDone(t, ctx, context.Canceled)
+		},
+	},
+	{
+		name: "WithDeadline later than parent",
+		run: func(t *testing.T, s Subject) {
+			deadline := s.Clock.Now().Add(Short)
+
+			parent, cancelParent := s.Clock.WithDeadline(context.Background(), deadline)
+			defer cancelParent()
+
+			ctx, cancel := s.Clock.WithDeadline(parent, deadline.Add(Long))
+			defer cancel()
+
+			if d, _ := ctx.Deadline(); !d.Equal(deadline) {
+				t.Errorf("Deadline() = %v, want %v", d, deadline)
+			}
+
+			s.Advance(Short)
+			expectDone(t, ctx, context.DeadlineExceeded)
+		},
+	},
+	{
+		name: "WithTimeout",
+		run: func(t *testing.T, s Subject) {
+			ctx, cancel := s.Clock.WithTimeout(context.Background(), Short)
+			defer cancel()
+
+			expectNotDone(t, ctx)
+			s.Advance(Short)
+			expectDone(t, ctx, context.DeadlineExceeded)
+		},
+	},
+	{
+		name: "WithTimeout cancel",
+		run: func(t *testing.T, s Subject) {
+			ctx, cancel := s.Clock.WithTimeout(context.Background(), Long)
+			cancel()
+			expectDone(t, ctx, context.Canceled)
+		},
+	},
+}
+
+func blockUntil(s Subject, n int) {
+	if s.BlockUntil != nil {
+		s.BlockUntil(n)
+	}
+}
+
+func expectFired[T any](t *testing.T, ch <-chan T) {
+	t.Helper()
+
+	select {
+	case <-ch:
+	case <-time.After(waitTimeout):
+		t.Fatal("expected a value")
+	}
+}
+
+func expectNotFired[T any](t *testing.T, ch <-chan T) {
+	t.Helper()
+
+	select {
+	case v := <-ch:
+		t.Fatalf("unexpected value: %v", v)
+	default:
+	}
+}
+
+func expectDone(t *testing.T, ctx context.Context, want error) {
+	t.Helper()
+
+	expectFired(t, ctx.Done())
+
+	if err := ctx.Err(); !errors.Is(err, want) {
+		t.Errorf("Err() = %v, want %v", err, want)
+	}
+}
+
+func expectNotDone(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	expectNotFired(t, ctx.Done())
+
+	if err := ctx.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func expectPanic(t *testing.T, want string, f func()) {
+	t.Helper()
+
+	defer func() {
+		if got := recover(); got != want {
+			t.Errorf("panic = %v, want %q", got, want)
+		}
+	}()
+
+	f()
+}
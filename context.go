@@ -6,7 +6,8 @@ import (
 	"github.com/itbasis/go-clock/v2/pkg"
 )
 
-// Default holds real clock implementation
+// Default holds real clock implementation.
+// Like the time package, it follows the fake time of a testing/synctest bubble when used from within one.
 var Default = New()
 
 // Used as a context key which holds clock value
@@ -18,7 +19,8 @@ func WithContext(ctx context.Context, clock pkg.Clock) context.Context {
 }
 
 // FromContext returns the implementation of clock associated with provided context.
-// It returns default implementation if not present.
+// It returns default implementation if not present, which follows the fake time of
+// a testing/synctest bubble when used from within one.
 func FromContext(ctx context.Context) pkg.Clock {
 	if ctx == nil {
 		panic("nil context passed to Clock")
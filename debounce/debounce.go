@@ -0,0 +1,187 @@
+// Package debounce implements debouncing and throttling of function calls with the timers of a pkg.Clock,
+// so tests using a mock clock control exactly when the function runs.
+package debounce
+
+import (
+	"sync"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Option configures a Debouncer.
+type Option func(*options)
+
+type options struct {
+	leading  bool
+	trailing bool
+	maxWait  time.Duration
+}
+
+// WithLeading sets whether the function runs on the first call of a burst. It does not by default.
+func WithLeading(leading bool) Option {
+	return func(o *options) { o.leading = leading }
+}
+
+// WithTrailing sets whether the function runs at the end of a burst if it was called since it last ran.
+// It does by default.
+func WithTrailing(trailing bool) Option {
+	return func(o *options) { o.trailing = trailing }
+}
+
+// WithMaxWait sets the maximum time a call waits during a long burst: the function also runs when the burst
+// has lasted the maximum wait since its start or since the function last ran this way, if it was called
+// in the meantime. A maximum wait less than or equal to zero is unlimited, which is the default.
+func WithMaxWait(maxWait time.Duration) Option {
+	return func(o *options) { o.maxWait = maxWait }
+}
+
+// Debouncer groups the calls separated by less than a wait into bursts and runs a function once per burst.
+// It is safe for concurrent use. The function runs in the goroutine calling Call or Flush for a leading or
+// flushed call, and in the goroutine of the clock timers otherwise.
+type Debouncer struct {
+	clock   pkg.Clock
+	wait    time.Duration
+	fn      func()
+	options options
+
+	mu       sync.Mutex
+	timer    pkg.Timer // ends the current burst, nil between bursts
+	maxTimer pkg.Timer // runs the function after the maximum wait, nil if unlimited
+	gen      uint64    // identifies the current timer, so a stopped timer firing late is ignored
+	burst    uint64    // identifies the current burst, so a stopped maximum wait timer firing late is ignored
+	pending  bool      // the function was called since it last ran
+}
+
+// Debounce returns a Debouncer running fn once the calls have stopped for the wait.
+// It panics if the wait is not positive.
+func Debounce(clock pkg.Clock, wait time.Duration, fn func(), opts ...Option) *Debouncer {
+	if wait <= 0 {
+		panic("non-positive wait for debounce.Debounce")
+	}
+
+	d := &Debouncer{clock: clock, wait: wait, fn: fn, options: options{trailing: true}}
+
+	for _, opt := range opts {
+		opt(&d.options)
+	}
+
+	return d
+}
+
+// Call starts a burst or extends the current one by the wait.
+func (d *Debouncer) Call() {
+	d.mu.Lock()
+
+	invoke := false
+
+	if d.timer == nil {
+		d.burst++
+
+		if d.options.maxWait > 0 {
+			d.maxTimer = d.clock.AfterFunc(d.options.maxWait, d.maxExpire(d.burst))
+		}
+
+		invoke = d.options.leading
+		d.pending = !invoke
+	} else {
+		d.timer.Stop()
+		d.pending = true
+	}
+
+	d.gen++
+	d.timer = d.clock.AfterFunc(d.wait, d.expire(d.gen))
+	d.mu.Unlock()
+
+	if invoke {
+		d.fn()
+	}
+}
+
+// Flush ends the current burst, running the function now if it was called since it last ran.
+func (d *Debouncer) Flush() {
+	d.mu.Lock()
+	invoke := d.pending
+	d.end()
+	d.mu.Unlock()
+
+	if invoke {
+		d.fn()
+	}
+}
+
+// Cancel ends the current burst without running the function.
+func (d *Debouncer) Cancel() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.end()
+}
+
+// Pending reports whether the function was called since it last ran.
+func (d *Debouncer) Pending() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.pending
+}
+
+// expire returns the function ending the burst once the timer of generation gen fires.
+func (d *Debouncer) expire(gen uint64) func() {
+	return func() {
+		d.mu.Lock()
+
+		if gen != d.gen {
+			d.mu.Unlock()
+
+			return
+		}
+
+		invoke := d.pending && d.options.trailing
+		d.end()
+		d.mu.Unlock()
+
+		if invoke {
+			d.fn()
+		}
+	}
+}
+
+// maxExpire returns the function running the pending call once the burst has lasted the maximum wait.
+func (d *Debouncer) maxExpire(burst uint64) func() {
+	return func() {
+		d.mu.Lock()
+
+		if burst != d.burst {
+			d.mu.Unlock()
+
+			return
+		}
+
+		invoke := d.pending
+		d.pending = false
+		d.maxTimer = d.clock.AfterFunc(d.options.maxWait, d.maxExpire(burst))
+		d.mu.Unlock()
+
+		if invoke {
+			d.fn()
+		}
+	}
+}
+
+// end stops the timers of the current burst and forgets the pending call. d.mu must be held.
+func (d *Debouncer) end() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+
+	if d.maxTimer != nil {
+		d.maxTimer.Stop()
+		d.maxTimer = nil
+	}
+
+	d.gen++
+	d.burst++
+	d.pending = false
+}
@@ -0,0 +1,169 @@
+package debounce_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2"
+	"github.com/itbasis/go-clock/v2/clocktest"
+	"github.com/itbasis/go-clock/v2/debounce"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// recorder records the times at which a function runs, relative to the start of the mock clock.
+type recorder struct {
+	mock  pkg.Mock
+	start time.Time
+
+	mu    sync.Mutex
+	calls []time.Duration
+}
+
+func newRecorder(t *testing.T) *recorder {
+	mock := clocktest.New(t, clock.WithSyncAfterFunc())
+
+	return &recorder{mock: mock, start: mock.Now()}
+}
+
+func (r *recorder) run() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, r.mock.Since(r.start))
+}
+
+func (r *recorder) expect(t *testing.T, want ...time.Duration) {
+	t.Helper()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.calls) != len(want) {
+		t.Fatalf("ran at %v, want %v", r.calls, want)
+	}
+
+	for i := range want {
+		if r.calls[i] != want[i] {
+			t.Fatalf("ran at %v, want %v", r.calls, want)
+		}
+	}
+}
+
+// Ensure that the function runs once the calls have stopped for the wait.
+func TestDebounce(t *testing.T) {
+	r := newRecorder(t)
+	d := debounce.Debounce(r.mock, time.Second, r.run)
+
+	d.Call()
+	r.mock.Add(500 * time.Millisecond)
+	d.Call()
+	r.mock.Add(400 * time.Millisecond)
+	d.Call()
+
+	r.mock.Add(999 * time.Millisecond)
+	r.expect(t)
+
+	r.mock.Add(time.Millisecond)
+	r.expect(t, 1900*time.Millisecond)
+
+	r.mock.Add(time.Hour)
+	r.expect(t, 1900*time.Millisecond)
+}
+
+// Ensure that the function runs on the first call of a burst with WithLeading.
+func TestDebounce_Leading(t *testing.T) {
+	r := newRecorder(t)
+	d := debounce.Debounce(r.mock, time.Second, r.run, debounce.WithLeading(true), debounce.WithTrailing(false))
+
+	d.Call()
+	r.mock.Add(500 * time.Millisecond)
+	d.Call()
+	r.mock.Add(1500 * time.Millisecond)
+	d.Call()
+	r.mock.Add(time.Second)
+
+	r.expect(t, 0, 2*time.Second)
+
+	// With both edges, a single call runs the function once.
+	r = newRecorder(t)
+	d = debounce.Debounce(r.mock, time.Second, r.run, debounce.WithLeading(true))
+
+	d.Call()
+	r.mock.Add(2 * time.Second)
+	d.Call()
+	r.mock.Add(500 * time.Millisecond)
+	d.Call()
+	r.mock.Add(2 * time.Second)
+
+	r.expect(t, 0, 2*time.Second, 3500*time.Millisecond)
+}
+
+// Ensure that the function runs at least once per maximum wait during a long burst.
+func TestDebounce_MaxWait(t *testing.T) {
+	r := newRecorder(t)
+	d := debounce.Debounce(r.mock, time.Second, r.run, debounce.WithMaxWait(3*time.Second))
+
+	for range 10 {
+		d.Call()
+		r.mock.Add(500 * time.Millisecond)
+	}
+
+	r.mock.Add(time.Second)
+
+	r.expect(t, 3*time.Second, 5500*time.Millisecond)
+}
+
+// Ensure that Flush runs the pending call now and Cancel drops it.
+func TestDebounce_FlushCancel(t *testing.T) {
+	r := newRecorder(t)
+	d := debounce.Debounce(r.mock, time.Second, r.run)
+
+	d.Flush()
+	d.Call()
+	r.mock.Add(500 * time.Millisecond)
+
+	if !d.Pending() {
+		t.Fatal("Pending() = false, want true")
+	}
+
+	d.Flush()
+	r.expect(t, 500*time.Millisecond)
+
+	if d.Pending() {
+		t.Fatal("Pending() after Flush = true, want false")
+	}
+
+	d.Call()
+	d.Cancel()
+	r.mock.Add(time.Hour)
+	r.expect(t, 500*time.Millisecond)
+}
+
+// Ensure that the throttled function runs at most once per interval.
+func TestThrottle(t *testing.T) {
+	r := newRecorder(t)
+	th := debounce.Throttle(r.mock, time.Second, r.run)
+
+	for range 8 {
+		th.Call()
+		r.mock.Add(300 * time.Millisecond)
+	}
+
+	r.mock.Add(2 * time.Second)
+	r.expect(t, 0, time.Second, 2*time.Second, 3*time.Second)
+
+	// Idle again: the next call runs at once.
+	th.Call()
+	r.expect(t, 0, time.Second, 2*time.Second, 3*time.Second, 4400*time.Millisecond)
+
+	th.Call()
+	r.mock.Add(200 * time.Millisecond)
+	th.Flush()
+	r.expect(t, 0, time.Second, 2*time.Second, 3*time.Second, 4400*time.Millisecond, 4600*time.Millisecond)
+
+	th.Call()
+	th.Cancel()
+	r.mock.Add(time.Hour)
+	r.expect(t, 0, time.Second, 2*time.Second, 3*time.Second, 4400*time.Millisecond, 4600*time.Millisecond)
+}
@@ -0,0 +1,120 @@
+package debounce
+
+import (
+	"sync"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Throttler runs a function at most once per interval: on the first call, then at the end of each interval
+// if it was called during the interval. It is safe for concurrent use. The function runs in the goroutine
+// calling Call or Flush when the throttler is idle or flushed, and in the goroutine of the clock timers otherwise.
+type Throttler struct {
+	clock    pkg.Clock
+	interval time.Duration
+	fn       func()
+
+	mu      sync.Mutex
+	timer   pkg.Timer // ends the current interval, nil while idle
+	gen     uint64    // identifies the current timer, so a stopped timer firing late is ignored
+	pending bool      // the function was called during the current interval
+}
+
+// Throttle returns a Throttler running fn at most once per interval.
+// It panics if the interval is not positive.
+func Throttle(clock pkg.Clock, interval time.Duration, fn func()) *Throttler {
+	if interval <= 0 {
+		panic("non-positive interval for debounce.Throttle")
+	}
+
+	return &Throttler{clock: clock, interval: interval, fn: fn}
+}
+
+// Call runs the function now if the throttler is idle, or at the end of the current interval otherwise.
+func (t *Throttler) Call() {
+	t.mu.Lock()
+
+	if t.timer != nil {
+		t.pending = true
+		t.mu.Unlock()
+
+		return
+	}
+
+	t.start()
+	t.mu.Unlock()
+
+	t.fn()
+}
+
+// Flush runs the function now if it was called during the current interval, starting a new interval.
+func (t *Throttler) Flush() {
+	t.mu.Lock()
+
+	if !t.pending {
+		t.mu.Unlock()
+
+		return
+	}
+
+	t.timer.Stop()
+	t.start()
+	t.mu.Unlock()
+
+	t.fn()
+}
+
+// Cancel forgets the call pending at the end of the current interval and makes the throttler idle.
+func (t *Throttler) Cancel() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+
+	t.gen++
+	t.pending = false
+}
+
+// Pending reports whether the function was called during the current interval.
+func (t *Throttler) Pending() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.pending
+}
+
+// start starts a new interval. t.mu must be held.
+func (t *Throttler) start() {
+	t.gen++
+	t.pending = false
+	t.timer = t.clock.AfterFunc(t.interval, t.expire(t.gen))
+}
+
+// expire returns the function ending the interval once the timer of generation gen fires.
+func (t *Throttler) expire(gen uint64) func() {
+	return func() {
+		t.mu.Lock()
+
+		if gen != t.gen {
+			t.mu.Unlock()
+
+			return
+		}
+
+		if !t.pending {
+			t.timer = nil
+			t.mu.Unlock()
+
+			return
+		}
+
+		t.start()
+		t.mu.Unlock()
+
+		t.fn()
+	}
+}
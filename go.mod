@@ -1,3 +1,3 @@
 module github.com/itbasis/go-clock/v2
 
-go 1.21
+go 1.23
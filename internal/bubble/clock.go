@@ -0,0 +1,58 @@
+//go:build go1.25
+
+// Package bubble implements a clock following the fake time of a testing/synctest bubble.
+package bubble
+
+import (
+	"fmt"
+	"testing/synctest"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/impl"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Clock wraps the time package, which uses the fake time of the bubble of the calling goroutine,
+// and moves this fake time forward by sleeping.
+type Clock struct {
+	pkg.Clock
+}
+
+func NewClock() *Clock {
+	return &Clock{Clock: impl.NewClock()}
+}
+
+// Add moves the fake time of the bubble forward by the duration, then waits until every
+// other goroutine of the bubble is durably blocked.
+// The fake time moves forward while every goroutine of the bubble is durably blocked,
+// so the other goroutines run until they block at each timer due before the new time.
+func (c *Clock) Add(duration time.Duration) {
+	time.Sleep(duration)
+	synctest.Wait()
+}
+
+// Set moves the fake time of the bubble forward to t, then waits until every
+// other goroutine of the bubble is durably blocked.
+// It panics with the error of TrySet.
+func (c *Clock) Set(t time.Time) {
+	if err := c.TrySet(t); err != nil {
+		panic(err)
+	}
+}
+
+// TrySet is like Set but returns an error wrapping pkg.ErrBackwardJump if t is before the current time.
+func (c *Clock) TrySet(t time.Time) error {
+	now := time.Now()
+	if t.Before(now) {
+		return fmt.Errorf("%w: from %s to %s", pkg.ErrBackwardJump, now, t)
+	}
+
+	c.Add(t.Sub(now))
+
+	return nil
+}
+
+// Wait waits until every other goroutine of the bubble is durably blocked.
+func (c *Clock) Wait() {
+	synctest.Wait()
+}
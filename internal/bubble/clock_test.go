@@ -0,0 +1,96 @@
+//go:build go1.25
+
+package bubble_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/bubble"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Ensure that the clock shares the fake time of the bubble with the time package.
+func TestClock_Add(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		clock := bubble.NewClock()
+		start := clock.Now()
+
+		var slept, waited time.Time
+
+		go func() {
+			time.Sleep(time.Second)
+			slept = time.Now()
+		}()
+
+		go func() {
+			<-clock.After(2 * time.Second)
+			waited = clock.Now()
+		}()
+
+		clock.Add(3 * time.Second)
+
+		if !slept.Equal(start.Add(time.Second)) {
+			t.Errorf("time.Sleep returned at %v, want %v", slept, start.Add(time.Second))
+		}
+
+		if !waited.Equal(start.Add(2 * time.Second)) {
+			t.Errorf("After fired at %v, want %v", waited, start.Add(2*time.Second))
+		}
+
+		if d := time.Since(start); d != 3*time.Second {
+			t.Errorf("time.Since() = %v, want 3s", d)
+		}
+	})
+}
+
+// Ensure that contexts created by the clock expire with the fake time of the bubble.
+func TestClock_WithTimeout(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		clock := bubble.NewClock()
+
+		ctx, cancel := clock.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		clock.Add(time.Minute - time.Nanosecond)
+
+		if err := ctx.Err(); err != nil {
+			t.Fatalf("Err() = %v before the deadline", err)
+		}
+
+		clock.Add(time.Nanosecond)
+
+		if err := ctx.Err(); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Err() = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
+
+// Ensure that Set only moves the fake time forward.
+func TestClock_Set(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		clock := bubble.NewClock()
+		start := clock.Now()
+
+		clock.Set(start.Add(time.Hour))
+
+		if d := clock.Since(start); d != time.Hour {
+			t.Fatalf("Since() = %v, want 1h", d)
+		}
+
+		if err := clock.TrySet(start); !errors.Is(err, pkg.ErrBackwardJump) {
+			t.Errorf("TrySet() = %v, want %v", err, pkg.ErrBackwardJump)
+		}
+
+		defer func() {
+			if err, ok := recover().(error); !ok || !errors.Is(err, pkg.ErrBackwardJump) {
+				t.Errorf("recover() = %v, want %v", err, pkg.ErrBackwardJump)
+			}
+		}()
+
+		clock.Set(start)
+	})
+}
@@ -0,0 +1,176 @@
+// Package deadline implements the contexts done when a pkg.Clock reaches a deadline.
+package deadline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/impl"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// timerCtxKey is the key for which a context of this package returns itself, so it is found from derived contexts.
+type timerCtxKey struct{}
+
+// AfterFunc executes f after the duration on the clock of a context.
+// Executing f in the goroutine moving the clock makes the context done by the time the clock has moved.
+type AfterFunc func(d time.Duration, f func()) pkg.Timer
+
+// WithDeadline returns a copy of the parent context which is done when the clock reaches the deadline,
+// using afterFunc to wait for it.
+func WithDeadline(
+	clock pkg.Clock, afterFunc AfterFunc, parent context.Context, deadline time.Time,
+) (context.Context, context.CancelFunc) {
+	if cur, ok := Of(parent, clock); ok && cur.Before(deadline) {
+		// The current deadline is already sooner than the new one.
+		return context.WithCancel(parent)
+	}
+
+	ctx := &timerCtx{clock: clock, parent: parent, deadline: deadline, done: make(chan struct{})}
+	propagateCancel(parent, ctx)
+
+	dur := clock.Until(deadline)
+
+	if dur <= 0 {
+		ctx.cancel(context.DeadlineExceeded) // deadline has already passed
+
+		return ctx, func() {}
+	}
+
+	ctx.Lock()
+	defer ctx.Unlock()
+
+	if ctx.err == nil {
+		ctx.timer = afterFunc(
+			dur, func() {
+				ctx.cancel(context.DeadlineExceeded)
+			},
+		)
+	}
+
+	return ctx, func() { ctx.cancel(context.Canceled) }
+}
+
+// Of returns the deadline of the context if it was set with the clock, so it can be compared with its times.
+// A deadline set by the context package follows the time package, like the real-time clock.
+func Of(ctx context.Context, clock pkg.Clock) (time.Time, bool) {
+	d, ok := ctx.Deadline()
+	if !ok {
+		return time.Time{}, false
+	}
+
+	// A context derived from a context of this package reports the same deadline, unless it has its own.
+	if c, _ := ctx.Value(timerCtxKey{}).(*timerCtx); c != nil && c.deadline.Equal(d) {
+		return d, c.clock == clock
+	}
+
+	_, real := clock.(*impl.Clock)
+
+	return d, real
+}
+
+// propagateCancel arranges for child to be canceled when parent is.
+func propagateCancel(parent context.Context, child *timerCtx) {
+	if parent.Done() == nil {
+		return // parent is never canceled
+	}
+
+	go func() {
+		select {
+		case <-parent.Done():
+			child.cancel(parent.Err())
+		case <-child.Done():
+		}
+	}()
+}
+
+type timerCtx struct {
+	sync.Mutex
+
+	clock    pkg.Clock
+	parent   context.Context //nolint:containedctx
+	deadline time.Time
+	done     chan struct{}
+
+	err        error
+	timer      pkg.Timer
+	afterFuncs map[*func()]struct{} // functions to call on cancellation
+}
+
+func (c *timerCtx) cancel(err error) {
+	c.Lock()
+
+	if c.err != nil {
+		c.Unlock()
+
+		return // already canceled
+	}
+
+	c.err = err
+	close(c.done)
+
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+
+	afterFuncs := c.afterFuncs
+	c.afterFuncs = nil
+	c.Unlock()
+
+	for f := range afterFuncs {
+		(*f)()
+	}
+}
+
+// AfterFunc arranges to call f after the context is canceled.
+// The context package uses it to cancel children synchronously with their parent,
+// so derived contexts are done by the time the clock has moved.
+func (c *timerCtx) AfterFunc(f func()) func() bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.err != nil {
+		go f()
+
+		return func() bool { return false }
+	}
+
+	key := &f
+
+	if c.afterFuncs == nil {
+		c.afterFuncs = make(map[*func()]struct{})
+	}
+
+	c.afterFuncs[key] = struct{}{}
+
+	return func() bool {
+		c.Lock()
+		defer c.Unlock()
+
+		_, ok := c.afterFuncs[key]
+		delete(c.afterFuncs, key)
+
+		return ok
+	}
+}
+
+func (c *timerCtx) Deadline() (deadline time.Time, ok bool) { return c.deadline, true }
+
+func (c *timerCtx) Done() <-chan struct{} { return c.done }
+
+func (c *timerCtx) Err() error { return c.err }
+
+func (c *timerCtx) Value(key interface{}) interface{} {
+	if key == (timerCtxKey{}) {
+		return c
+	}
+
+	return c.parent.Value(key)
+}
+
+func (c *timerCtx) String() string {
+	return fmt.Sprintf("clock.WithDeadline(%s [%s])", c.deadline, c.deadline.Sub(c.clock.Now()))
+}
@@ -0,0 +1,133 @@
+// Package frozen implements a clock which can be frozen and unfrozen at runtime.
+package frozen
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/deadline"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Clock follows a base clock, except while it is frozen: the time stops and the timers are held.
+// On unfreeze, the time resumes from where it stopped and the held timers resume with their remaining durations.
+type Clock struct {
+	base pkg.Clock
+
+	// mu protects all other fields in this struct, and the timers.
+	mu     sync.Mutex
+	frozen bool
+	at     time.Time           // time when the clock was frozen
+	paused time.Duration       // total duration of the freezes
+	timers map[*Timer]struct{} // running timers, held while the clock is frozen
+}
+
+func NewClock(base pkg.Clock) *Clock {
+	return &Clock{base: base, timers: make(map[*Timer]struct{})}
+}
+
+// NewFrozenClock returns a clock frozen at t.
+func NewFrozenClock(base pkg.Clock, t time.Time) *Clock {
+	c := NewClock(base)
+	c.frozen = true
+	c.at = t
+	c.paused = base.Now().Sub(t)
+
+	return c
+}
+
+// Freeze stops the time and holds the timers, until Unfreeze is called.
+// It does nothing if the clock is already frozen.
+func (c *Clock) Freeze() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.frozen {
+		return
+	}
+
+	c.at = c.now()
+	c.frozen = true
+
+	for t := range c.timers {
+		t.hold()
+	}
+}
+
+// Unfreeze resumes the time from where it stopped, and the held timers with their remaining durations.
+// It does nothing if the clock is not frozen.
+func (c *Clock) Unfreeze() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.frozen {
+		return
+	}
+
+	c.frozen = false
+	c.paused = c.base.Now().Sub(c.at)
+
+	for t := range c.timers {
+		t.resume()
+	}
+}
+
+// Frozen reports whether the clock is frozen.
+func (c *Clock) Frozen() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.frozen
+}
+
+func (c *Clock) After(d time.Duration) <-chan time.Time { return c.Timer(d).Chan() }
+
+func (c *Clock) AfterFunc(d time.Duration, f func()) pkg.Timer { return c.newTimer(d, 0, f) }
+
+// Now returns the current time, which is constant while the clock is frozen.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now()
+}
+
+func (c *Clock) Since(t time.Time) time.Duration { return c.Now().Sub(t) }
+
+func (c *Clock) Until(t time.Time) time.Duration { return t.Sub(c.Now()) }
+
+func (c *Clock) Sleep(d time.Duration) { <-c.After(d) }
+
+func (c *Clock) Tick(d time.Duration) <-chan time.Time { return c.Ticker(d).Chan() }
+
+// Ticker creates a new ticker.
+// The duration must be greater than zero; if not, Ticker will panic.
+func (c *Clock) Ticker(d time.Duration) pkg.Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+
+	return &Ticker{timer: c.newTimer(d, d, nil)}
+}
+
+func (c *Clock) Timer(d time.Duration) pkg.Timer { return c.newTimer(d, 0, nil) }
+
+func (c *Clock) WithTimeout(parent context.Context, t time.Duration) (context.Context, context.CancelFunc) {
+	return c.WithDeadline(parent, c.Now().Add(t))
+}
+
+// WithDeadline returns a context done when the clock reaches the deadline.
+// The deadline is held with the timers while the clock is frozen.
+func (c *Clock) WithDeadline(parent context.Context, d time.Time) (context.Context, context.CancelFunc) {
+	return deadline.WithDeadline(c, c.AfterFunc, parent, d)
+}
+
+// now returns the current time. c.mu MUST be held when this method is called.
+func (c *Clock) now() time.Time {
+	if c.frozen {
+		return c.at
+	}
+
+	return c.base.Now().Add(-c.paused)
+}
@@ -0,0 +1,139 @@
+package frozen_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/clocktest"
+	"github.com/itbasis/go-clock/v2/internal/frozen"
+	"github.com/itbasis/go-clock/v2/internal/mock"
+)
+
+// Ensure that the time stops while the clock is frozen and resumes from where it stopped.
+func TestClock_Freeze(t *testing.T) {
+	base := mock.NewMock()
+	clock := frozen.NewClock(base)
+	start := clock.Now()
+
+	base.Add(time.Second)
+	clock.Freeze()
+
+	if !clock.Frozen() {
+		t.Fatal("Frozen() = false after Freeze")
+	}
+
+	base.Add(time.Hour)
+
+	if d := clock.Since(start); d != time.Second {
+		t.Fatalf("Since() = %v while frozen, want 1s", d)
+	}
+
+	clock.Unfreeze()
+	base.Add(time.Second)
+
+	if d := clock.Since(start); d != 2*time.Second {
+		t.Fatalf("Since() = %v after unfreeze, want 2s", d)
+	}
+}
+
+// Ensure that the timers are held while the clock is frozen and resume with their remaining durations.
+func TestClock_Freeze_Timers(t *testing.T) {
+	base := mock.NewMock()
+	clock := frozen.NewClock(base)
+	timer := clock.Timer(10 * time.Second)
+
+	base.Add(4 * time.Second)
+	clock.Freeze()
+	base.BlockUntil(0)
+	base.Add(time.Hour)
+	expectNotFired(t, timer.Chan())
+
+	clock.Unfreeze()
+	base.BlockUntil(1)
+	base.Add(6*time.Second - time.Nanosecond)
+	expectNotFired(t, timer.Chan())
+
+	base.Add(time.Nanosecond)
+	expectFired(t, timer.Chan())
+}
+
+// Ensure that a context deadline is held while the clock is frozen.
+func TestClock_Freeze_WithTimeout(t *testing.T) {
+	base := mock.NewMock()
+	clock := frozen.NewClock(base)
+
+	ctx, cancel := clock.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	clock.Freeze()
+	base.Add(time.Hour)
+
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("Err() = %v while frozen", err)
+	}
+
+	clock.Unfreeze()
+	base.BlockUntil(1)
+	base.Add(time.Minute)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context not done after the deadline")
+	}
+}
+
+// Ensure that a clock created frozen reports a constant time until unfrozen.
+func TestNewFrozenClock(t *testing.T) {
+	at := time.Date(2024, time.December, 31, 23, 59, 0, 0, time.UTC)
+	base := mock.NewMock()
+	clock := frozen.NewFrozenClock(base, at)
+
+	base.Add(time.Hour)
+
+	if now := clock.Now(); !now.Equal(at) {
+		t.Fatalf("Now() = %v, want %v", now, at)
+	}
+
+	clock.Unfreeze()
+	base.Add(time.Minute)
+
+	if now := clock.Now(); !now.Equal(at.Add(time.Minute)) {
+		t.Fatalf("Now() = %v, want %v", now, at.Add(time.Minute))
+	}
+}
+
+func TestClock_Conformance(t *testing.T) {
+	clocktest.RunConformance(
+		t, func(*testing.T) clocktest.Subject {
+			base := mock.NewMock()
+			s := clocktest.Mock(base)
+			s.Clock = frozen.NewClock(base)
+
+			return s
+		},
+	)
+}
+
+// expectFired fails the test if no value is received from the channel within a second.
+func expectFired(t *testing.T, ch <-chan time.Time) {
+	t.Helper()
+
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("expected a value")
+	}
+}
+
+// expectNotFired fails the test if a value is ready on the channel.
+func expectNotFired(t *testing.T, ch <-chan time.Time) {
+	t.Helper()
+
+	select {
+	case <-ch:
+		t.Fatal("unexpected value")
+	default:
+	}
+}
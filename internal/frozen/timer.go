@@ -0,0 +1,183 @@
+package frozen
+
+import (
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/timechan"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Timer is a timer of a freezable clock. It also implements the AfterFunc timers and the tickers.
+type Timer struct {
+	clock  *Clock
+	c      chan time.Time
+	fn     func()        // AfterFunc function, if set
+	period time.Duration // time between ticks, zero for timers
+	due    time.Time     // next fire time on the clock
+	timer  pkg.Timer     // base timer executing fire, nil while held
+	gen    uint64        // incremented whenever timer is replaced, so the previous one does not fire
+	active bool          // True if the timer has not fired nor been stopped
+}
+
+// newTimer creates and starts a timer.
+func (c *Clock) newTimer(d, period time.Duration, f func()) *Timer {
+	t := &Timer{clock: c, fn: f, period: period}
+	if f == nil {
+		t.c = make(chan time.Time, 1)
+	}
+
+	c.mu.Lock()
+	t.start(d)
+	c.mu.Unlock()
+
+	return t
+}
+
+func (t *Timer) Chan() <-chan time.Time { return t.c }
+
+// Stop prevents the timer from firing, whether it is armed or held by the frozen clock.
+// It returns true if the timer had not fired yet, or if its value had not been received, which is discarded.
+func (t *Timer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	return t.stop()
+}
+
+// Reset restarts the timer to fire after d of clock time. While the clock is frozen, the timer is held
+// and d counts from the unfreeze. It returns the same value as Stop would.
+func (t *Timer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	active := t.stop()
+	t.start(d)
+
+	return active
+}
+
+// start registers the timer on the clock, and arms it unless the clock is frozen.
+// Like the timers of the time package, a timer with a duration less than or equal to zero
+// fires immediately. t.clock.mu MUST be held when this method is called.
+func (t *Timer) start(d time.Duration) {
+	t.due = t.clock.now().Add(d)
+	t.active = true
+	t.clock.timers[t] = struct{}{}
+
+	switch {
+	case t.clock.frozen:
+	case d <= 0 && t.fn == nil:
+		t.expire()
+	default:
+		t.arm(d)
+	}
+}
+
+// stop unregisters the timer and drains its channel. t.clock.mu MUST be held
+// when this method is called.
+func (t *Timer) stop() bool {
+	active := t.active
+
+	t.active = false
+	t.hold()
+	delete(t.clock.timers, t)
+
+	return timechan.Drain(t.c) || active
+}
+
+// arm starts a base timer firing after d. t.clock.mu MUST be held
+// when this method is called.
+func (t *Timer) arm(d time.Duration) {
+	gen := t.gen
+	t.timer = t.clock.base.AfterFunc(d, func() { t.fire(gen) })
+}
+
+// hold stops the base timer. t.clock.mu MUST be held when this method is called.
+func (t *Timer) hold() {
+	t.gen++
+
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+}
+
+// resume arms the timer with its remaining duration. t.clock.mu MUST be held
+// when this method is called.
+func (t *Timer) resume() {
+	t.arm(t.due.Sub(t.clock.now()))
+}
+
+// fire sends the current time or executes the function of the timer, if the base timer gen is the current one.
+func (t *Timer) fire(gen uint64) {
+	t.clock.mu.Lock()
+
+	if t.gen != gen || !t.active {
+		t.clock.mu.Unlock()
+
+		return
+	}
+
+	f := t.expire()
+	t.clock.mu.Unlock()
+
+	if f != nil {
+		f()
+	}
+}
+
+// expire sends the current time on the channel, and rearms the ticker or unregisters the timer.
+// It returns the AfterFunc function to execute once the lock is released, if any.
+// t.clock.mu MUST be held when this method is called.
+func (t *Timer) expire() func() {
+	now := t.clock.now()
+
+	if t.period > 0 {
+		// Like time.Ticker, skip the ticks missed by a slow receiver.
+		for !t.due.After(now) {
+			t.due = t.due.Add(t.period)
+		}
+
+		t.arm(t.due.Sub(now))
+	} else {
+		t.active = false
+		t.timer = nil
+		delete(t.clock.timers, t)
+	}
+
+	if t.fn == nil {
+		select {
+		case t.c <- now:
+		default:
+		}
+	}
+
+	return t.fn
+}
+
+// Ticker holds a channel that receives "ticks" at regular intervals.
+type Ticker struct {
+	timer *Timer
+}
+
+func (t *Ticker) Chan() <-chan time.Time { return t.timer.c }
+
+// Stop turns off the ticker, whether it is armed or held by the frozen clock, and discards the tick
+// not received yet.
+func (t *Ticker) Stop() { t.timer.Stop() }
+
+// Reset restarts the ticker with the period d of clock time, discarding the tick not received yet.
+// While the clock is frozen, the ticker is held and the first period counts from the unfreeze.
+// The duration must be greater than zero; if not, Reset will panic.
+func (t *Ticker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+
+	t.timer.clock.mu.Lock()
+	defer t.timer.clock.mu.Unlock()
+
+	t.timer.stop()
+	t.timer.period = d
+	t.timer.start(d)
+}
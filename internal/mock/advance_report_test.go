@@ -0,0 +1,86 @@
+package mock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/mock"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Ensure that AddAndReport reports the fired timers in firing order.
+func TestMock_AddAndReport(t *testing.T) {
+	clock := mock.NewMock()
+	start := clock.Now()
+
+	timer := clock.Timer(2 * time.Second)
+	timer.(pkg.Labeler).SetLabel("timeout")
+	clock.AfterFunc(3*time.Second, func() {})
+
+	_, cancel := clock.WithTimeout(context.Background(), 4*time.Second)
+	defer cancel()
+
+	report := clock.AddAndReport(5 * time.Second)
+
+	if !report.From.Equal(start) || !report.To.Equal(start.Add(5*time.Second)) {
+		t.Errorf("report from %v to %v, want from %v to %v", report.From, report.To, start, start.Add(5*time.Second))
+	}
+
+	want := []pkg.Event{
+		{Kind: pkg.KindTimer, Label: "timeout", Time: start.Add(2 * time.Second)},
+		{Kind: pkg.KindAfterFunc, Time: start.Add(3 * time.Second)},
+		{Kind: pkg.KindContextDeadline, Time: start.Add(4 * time.Second)},
+	}
+
+	if len(report.Events) != len(want) {
+		t.Fatalf("events = %v, want %v", report.Events, want)
+	}
+
+	for i, e := range report.Events {
+		if e.Kind != want[i].Kind || e.Label != want[i].Label || !e.Time.Equal(want[i].Time) || e.Dropped {
+			t.Errorf("event %d = %v, want %v", i, e, want[i])
+		}
+	}
+}
+
+// Ensure that AddAndReport reports the ticks dropped because the channel was full.
+func TestMock_AddAndReport_Dropped(t *testing.T) {
+	clock := mock.NewMock()
+
+	ticker := clock.Ticker(time.Second)
+	ticker.(pkg.Labeler).SetLabel("heartbeat")
+
+	report := clock.AddAndReport(5 * time.Second)
+
+	if n := report.Fired("heartbeat"); n != 5 {
+		t.Errorf("Fired() = %d, want 5", n)
+	}
+
+	if n := report.Dropped("heartbeat"); n != 4 {
+		t.Errorf("Dropped() = %d, want 4", n)
+	}
+
+	if report.Events[0].Dropped {
+		t.Error("first tick dropped")
+	}
+}
+
+// Ensure that SetAndReport reports the fired timers.
+func TestMock_SetAndReport(t *testing.T) {
+	clock := mock.NewMock()
+	start := clock.Now().Round(0) // Set moves the wall clock only
+
+	clock.Timer(time.Minute)
+	clock.Timer(time.Hour)
+
+	report := clock.SetAndReport(start.Add(10 * time.Minute))
+
+	if len(report.Events) != 1 || !report.Events[0].Time.Equal(start.Add(time.Minute)) {
+		t.Fatalf("events = %v, want a timer at %v", report.Events, start.Add(time.Minute))
+	}
+
+	if report := clock.SetAndReport(start.Add(20 * time.Minute)); len(report.Events) != 0 {
+		t.Fatalf("events = %v, want none", report.Events)
+	}
+}
@@ -0,0 +1,54 @@
+package mock
+
+import (
+	"context"
+)
+
+// AutoAdvance moves the clock forward to the next pending timer and fires it
+// whenever the clock is idle, like a discrete-event simulator, until the context is done.
+//
+// The clock is idle when at least n goroutines may be parked on it and the functions passed to AfterFunc
+// are settled: they have returned or wait on the clock. A goroutine may be parked on each pending timer,
+// sleep, AfterFunc function and context deadline, and on each ticker whose previous tick has been received:
+// a ticker nobody receives from does not keep the clock moving.
+// With n less than or equal to zero, only the functions are waited for, and AutoAdvance returns nil
+// once no goroutine may be parked on the clock. Otherwise, it returns the context error when the context is done.
+func (m *Mock) AutoAdvance(ctx context.Context, n int) error {
+	var stopped bool
+
+	idle := func() bool {
+		if !m.settled() {
+			return false
+		}
+
+		waiters, _ := m.waiters()
+		stopped = n <= 0 && waiters == 0
+
+		return waiters >= max(n, 1) || stopped
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if err := m.waitFor(ctx, idle); err != nil {
+			return err
+		}
+
+		if stopped {
+			return nil
+		}
+
+		m.mu.Lock()
+
+		// An overdue timer fires at the current time.
+		next := m.timers[0].Next()
+		if next.Before(m.now) {
+			next = m.now
+		}
+		m.mu.Unlock()
+
+		m.runNextTimer(next, true)
+	}
+}
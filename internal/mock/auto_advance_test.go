@@ -0,0 +1,159 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/mock"
+)
+
+// Ensure that AutoAdvance fast-forwards sleeping goroutines.
+func TestMock_AutoAdvance(t *testing.T) {
+	var (
+		clock       = mock.NewMock()
+		woken       = make(chan time.Time, 3)
+		ctx, cancel = context.WithCancel(context.Background())
+		done        = make(chan error)
+	)
+
+	go func() {
+		for i := 0; i < 3; i++ {
+			clock.Sleep(time.Minute)
+			woken <- clock.Now()
+		}
+
+		close(woken)
+	}()
+
+	go func() { done <- clock.AutoAdvance(ctx, 1) }()
+
+	var last time.Time
+	for last = range woken {
+	}
+
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+
+	if !last.Equal(time.Unix(180, 0)) {
+		t.Fatalf("expected the goroutine to wake up at 3m, got: %v", last)
+	}
+}
+
+// Ensure that AutoAdvance fires timers created by AfterFunc in chronological order.
+func TestMock_AutoAdvance_AfterFunc(t *testing.T) {
+	var (
+		clock = mock.NewMock()
+		fired = make(chan time.Duration, 3)
+	)
+
+	for _, d := range []time.Duration{3 * time.Second, time.Second, 2 * time.Second} {
+		d := d
+
+		clock.AfterFunc(d, func() { fired <- d })
+	}
+
+	if err := clock.AutoAdvance(context.Background(), 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for want := time.Second; want <= 3*time.Second; want += time.Second {
+		if got := <-fired; got != want {
+			t.Fatalf("expected %v, got: %v", want, got)
+		}
+	}
+}
+
+// Ensure that AutoAdvance waits for the functions sleeping on the clock.
+func TestMock_AutoAdvance_AfterFuncSleep(t *testing.T) {
+	var (
+		clock = mock.NewMock()
+		woken = make(chan time.Time, 1)
+	)
+
+	clock.AfterFunc(
+		time.Second, func() {
+			clock.Sleep(time.Minute)
+			woken <- clock.Now()
+		},
+	)
+
+	if err := clock.AutoAdvance(context.Background(), 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if now := <-woken; !now.Equal(time.Unix(61, 0)) {
+		t.Fatalf("expected the function to wake up at 61s, got: %v", now)
+	}
+}
+
+// Ensure that AutoAdvance stops when the context is done.
+func TestMock_AutoAdvance_Cancel(t *testing.T) {
+	clock := mock.NewMock()
+	ticker := clock.Ticker(time.Second)
+
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-ticker.Chan()
+		<-ticker.Chan()
+		cancel()
+	}()
+
+	if err := clock.AutoAdvance(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+
+	if now := clock.Now(); now.Before(time.Unix(2, 0)) {
+		t.Fatalf("expected at least 2 ticks, got time: %v", now)
+	}
+}
+
+// Ensure that AutoAdvance stops moving the clock once the ticks of a ticker are not received anymore.
+func TestMock_AutoAdvance_TickerNotReceived(t *testing.T) {
+	var (
+		clock       = mock.NewMock()
+		ticker      = clock.Ticker(time.Second)
+		received    = make(chan struct{})
+		ctx, cancel = context.WithCancel(context.Background())
+		done        = make(chan error)
+	)
+
+	defer ticker.Stop()
+
+	go func() {
+		defer close(received)
+
+		for i := 0; i < 3; i++ {
+			<-ticker.Chan()
+		}
+	}()
+
+	go func() { done <- clock.AutoAdvance(ctx, 1) }()
+
+	<-received
+
+	// The fourth tick is fired once the third one is received, and is never received.
+	for i := 0; i < 10; i++ {
+		time.Sleep(time.Millisecond)
+
+		if now := clock.Now(); now.After(time.Unix(4, 0)) {
+			t.Fatalf("Now() = %v, want the clock stopped at %v", now, time.Unix(4, 0))
+		}
+	}
+
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+
+	if now := clock.Now(); now.Before(time.Unix(3, 0)) {
+		t.Fatalf("Now() = %v, want at least 3 ticks", now)
+	}
+}
@@ -0,0 +1,191 @@
+package mock_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/mock"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+var jumpStart = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// newJumpedMock creates a timer of 10s and a ticker of 4s, then moves the clock
+// forward by 6s and back by 1h with the given policy.
+func newJumpedMock(t *testing.T, policy pkg.BackwardJump) (*mock.Mock, pkg.Timer, pkg.Ticker) {
+	t.Helper()
+
+	clock := mock.NewMockAt(jumpStart, mock.WithBackwardJump(policy))
+	timer := clock.Timer(10 * time.Second)
+	ticker := clock.Ticker(4 * time.Second)
+
+	clock.Add(6 * time.Second)
+	expectTickAt(t, ticker.Chan(), jumpStart.Add(4*time.Second))
+
+	clock.Set(jumpStart.Add(-time.Hour))
+
+	return clock, timer, ticker
+}
+
+// Ensure that timers keep their fire times on backward jumps by default.
+func TestMock_Set_BackwardJumpKeep(t *testing.T) {
+	clock, timer, ticker := newJumpedMock(t, pkg.BackwardJumpKeep)
+
+	next, ok := clock.NextDeadline()
+	if !ok || !next.Equal(jumpStart.Add(8*time.Second)) {
+		t.Fatalf("NextDeadline() = %v, %v; want %v", next, ok, jumpStart.Add(8*time.Second))
+	}
+
+	clock.Add(time.Hour)
+	expectNoTick(t, timer.Chan())
+	expectNoTick(t, ticker.Chan())
+
+	clock.Add(8 * time.Second)
+	expectTickAt(t, ticker.Chan(), jumpStart.Add(8*time.Second))
+	expectNoTick(t, timer.Chan())
+
+	clock.Add(2 * time.Second)
+	expectTickAt(t, timer.Chan(), jumpStart.Add(10*time.Second))
+}
+
+// Ensure that Set panics on backward jumps with the reject policy.
+func TestMock_Set_BackwardJumpReject(t *testing.T) {
+	clock := mock.NewMockAt(jumpStart, mock.WithBackwardJump(pkg.BackwardJumpReject))
+	timer := clock.Timer(time.Second)
+
+	func() {
+		defer func() {
+			err, ok := recover().(error)
+			if !ok || !errors.Is(err, pkg.ErrBackwardJump) {
+				t.Errorf("recover() = %v, want %v", err, pkg.ErrBackwardJump)
+			}
+		}()
+
+		clock.Set(jumpStart.Add(-time.Second))
+	}()
+
+	if now := clock.Now(); !now.Equal(jumpStart) {
+		t.Errorf("Now() = %v, want %v", now, jumpStart)
+	}
+
+	// The clock is still usable, and forward jumps are allowed.
+	clock.Set(jumpStart.Add(time.Second))
+	expectTickAt(t, timer.Chan(), jumpStart.Add(time.Second))
+}
+
+// Ensure that TrySet returns the error of backward jumps with the reject policy instead of panicking.
+func TestMock_TrySet(t *testing.T) {
+	for _, tt := range []struct {
+		policy  pkg.BackwardJump
+		to      time.Duration
+		wantErr error
+		wantNow time.Time
+	}{
+		{pkg.BackwardJumpReject, -time.Second, pkg.ErrBackwardJump, jumpStart},
+		{pkg.BackwardJumpReject, time.Second, nil, jumpStart.Add(time.Second)},
+		{pkg.BackwardJumpKeep, -time.Second, nil, jumpStart.Add(-time.Second)},
+	} {
+		t.Run(tt.policy.String()+"/"+tt.to.String(), func(t *testing.T) {
+			clock := mock.NewMockAt(jumpStart, mock.WithBackwardJump(tt.policy))
+
+			if err := clock.TrySet(jumpStart.Add(tt.to)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("TrySet() = %v, want %v", err, tt.wantErr)
+			}
+
+			if now := clock.Now(); !now.Equal(tt.wantNow) {
+				t.Errorf("Now() = %v, want %v", now, tt.wantNow)
+			}
+		})
+	}
+}
+
+// Ensure that timers keep their remaining durations on backward jumps with the wall only policy.
+func TestMock_Set_BackwardJumpWallOnly(t *testing.T) {
+	clock, timer, ticker := newJumpedMock(t, pkg.BackwardJumpWallOnly)
+	base := jumpStart.Add(-time.Hour)
+
+	clock.Add(2 * time.Second)
+	expectTickAt(t, ticker.Chan(), base.Add(2*time.Second))
+	expectNoTick(t, timer.Chan())
+
+	clock.Add(2 * time.Second)
+	expectTickAt(t, timer.Chan(), base.Add(4*time.Second))
+
+	clock.Add(2 * time.Second)
+	expectTickAt(t, ticker.Chan(), base.Add(6*time.Second))
+}
+
+// Ensure that timers restart from the new time with their remaining durations on backward jumps
+// with the rebase policy.
+func TestMock_Set_BackwardJumpRebase(t *testing.T) {
+	clock, timer, ticker := newJumpedMock(t, pkg.BackwardJumpRebase)
+	base := jumpStart.Add(-time.Hour)
+
+	// 4s of the 10s timer and 2s of the 4s period of the ticker remain.
+	infos := clock.PendingTimers()
+	if len(infos) != 2 {
+		t.Fatalf("PendingTimers() = %v, want 2 timers", infos)
+	}
+
+	for i, want := range []time.Time{base.Add(2 * time.Second), base.Add(4 * time.Second)} {
+		if !infos[i].Next.Equal(want) {
+			t.Errorf("PendingTimers()[%d].Next = %v, want %v", i, infos[i].Next, want)
+		}
+	}
+
+	clock.Add(2 * time.Second)
+	expectTickAt(t, ticker.Chan(), base.Add(2*time.Second))
+	expectNoTick(t, timer.Chan())
+
+	clock.Add(2 * time.Second)
+	expectTickAt(t, timer.Chan(), base.Add(4*time.Second))
+
+	clock.Add(2 * time.Second)
+	expectTickAt(t, ticker.Chan(), base.Add(6*time.Second))
+}
+
+// Ensure that forward jumps fire the due timers unless they follow the monotonic clock.
+func TestMock_Set_ForwardJump(t *testing.T) {
+	for _, policy := range []pkg.BackwardJump{
+		pkg.BackwardJumpKeep, pkg.BackwardJumpReject, pkg.BackwardJumpRebase,
+	} {
+		t.Run(policy.String(), func(t *testing.T) {
+			clock := mock.NewMockAt(jumpStart, mock.WithBackwardJump(policy))
+			timer := clock.Timer(time.Minute)
+
+			clock.Set(jumpStart.Add(time.Hour))
+			expectTickAt(t, timer.Chan(), jumpStart.Add(time.Minute))
+		})
+	}
+}
+
+// Ensure that timers keep their remaining durations on forward jumps with the wall only policy.
+func TestMock_Set_ForwardJumpWallOnly(t *testing.T) {
+	clock := mock.NewMockAt(jumpStart, mock.WithBackwardJump(pkg.BackwardJumpWallOnly))
+	timer := clock.Timer(time.Minute)
+
+	clock.Add(10 * time.Second)
+	clock.Set(jumpStart.Add(time.Hour))
+	expectNoTick(t, timer.Chan())
+
+	clock.Add(49 * time.Second)
+	expectNoTick(t, timer.Chan())
+
+	clock.Add(time.Second)
+	expectTickAt(t, timer.Chan(), jumpStart.Add(time.Hour+50*time.Second))
+}
+
+// expectTickAt fails the test if the value ready on the channel is not want.
+func expectTickAt(t *testing.T, ch <-chan time.Time, want time.Time) {
+	t.Helper()
+
+	select {
+	case got := <-ch:
+		if !got.Equal(want) {
+			t.Fatalf("tick at %v, want %v", got, want)
+		}
+	default:
+		t.Fatal("too late")
+	}
+}
@@ -0,0 +1,7 @@
+package mock
+
+// blocker represents a caller of BlockUntil waiting for a number of registered timers.
+type blocker struct {
+	count int
+	ch    chan struct{}
+}
@@ -0,0 +1,65 @@
+package mock
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+const internalPrefix = "github.com/itbasis/go-clock/v2/internal/"
+
+// callers returns the program counters of the depth innermost frames of the goroutine creating a timer,
+// or nil if depth is not positive.
+func callers(depth int) []uintptr {
+	if depth <= 0 {
+		return nil
+	}
+
+	pcs := make([]uintptr, depth)
+	n := runtime.Callers(2, pcs) //nolint:gomnd // skip runtime.Callers and callers
+
+	return pcs[:n]
+}
+
+// formatCallers returns the location of the first caller outside the internals of the module,
+// and the stack from this caller.
+func formatCallers(pcs []uintptr) (caller, stack string) {
+	if len(pcs) == 0 {
+		return "", ""
+	}
+
+	var sb strings.Builder
+
+	frames := runtime.CallersFrames(pcs)
+
+	for {
+		frame, more := frames.Next()
+
+		if caller == "" && !isInternalFunction(frame.Function) {
+			caller = fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+
+		if caller != "" {
+			fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return caller, sb.String()
+}
+
+// isInternalFunction reports whether the function belongs to an internal package of the module.
+func isInternalFunction(function string) bool {
+	pkgPath := function
+
+	if i := strings.LastIndex(pkgPath, "/"); i >= 0 {
+		if j := strings.Index(pkgPath[i:], "."); j >= 0 {
+			pkgPath = pkgPath[:i+j]
+		}
+	}
+
+	return strings.HasPrefix(pkgPath, internalPrefix) && !strings.HasSuffix(pkgPath, "_test")
+}
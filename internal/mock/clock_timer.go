@@ -2,10 +2,34 @@ package mock
 
 import (
 	"time"
+
+	"github.com/itbasis/go-clock/v2/pkg"
 )
 
 // clockTimer represents an object with an associated start time.
 type clockTicker interface {
 	Next() time.Time
-	Tick(time.Time)
+
+	// fire fires the timer at now, which unregisters a timer and schedules the next tick of a ticker.
+	// The mock clock lock MUST be held when this method is called. The returned function, if not nil,
+	// MUST be called once the lock is released to complete the delivery, and may update the event.
+	fire(now time.Time) (pkg.Event, func(event *pkg.Event))
+
+	// setNext changes the next tick time; the timer MUST be fixed in the heap afterwards.
+	setNext(next time.Time)
+
+	slot() *heapSlot
+	info() pkg.TimerInfo
 }
+
+// heapSlot holds the position of a timer in the heap of the mock clock.
+type heapSlot struct {
+	index int    // index in the heap, -1 if not scheduled
+	seq   uint64 // registration order, breaks ties between timers with the same next tick time
+}
+
+func newHeapSlot() heapSlot { return heapSlot{index: -1} }
+
+func (s *heapSlot) slot() *heapSlot { return s }
+
+func (s *heapSlot) scheduled() bool { return s.index >= 0 }
@@ -1,8 +1,41 @@
 package mock
 
-// ClockTimers represents a list of sortable timers.
+// ClockTimers represents a min-heap of timers ordered by their next tick time.
+// Timers with the same next tick time are ordered by creation.
 type clockTickers []clockTicker
 
-func (a clockTickers) Len() int           { return len(a) }
-func (a clockTickers) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a clockTickers) Less(i, j int) bool { return a[i].Next().Before(a[j].Next()) }
+func (a clockTickers) Len() int { return len(a) }
+
+func (a clockTickers) Swap(i, j int) {
+	a[i], a[j] = a[j], a[i]
+	a[i].slot().index = i
+	a[j].slot().index = j
+}
+
+func (a clockTickers) Less(i, j int) bool { return lessClockTickers(a[i], a[j]) }
+
+func (a *clockTickers) Push(x any) {
+	t := x.(clockTicker) //nolint:forcetypeassert
+	t.slot().index = len(*a)
+	*a = append(*a, t)
+}
+
+func (a *clockTickers) Pop() any {
+	old := *a
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	*a = old[:n-1]
+	t.slot().index = -1
+
+	return t
+}
+
+// lessClockTickers reports whether the timer a fires before the timer b.
+func lessClockTickers(a, b clockTicker) bool {
+	if next, other := a.Next(), b.Next(); !next.Equal(other) {
+		return next.Before(other)
+	}
+
+	return a.slot().seq < b.slot().seq
+}
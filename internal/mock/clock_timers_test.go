@@ -0,0 +1,166 @@
+package mock_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/mock"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+var benchmarkSizes = []int{1_000, 10_000, 100_000}
+
+// newBenchmarkMock returns a mock clock with n pending AfterFunc timers.
+func newBenchmarkMock(n int) (*mock.Mock, []pkg.Timer) {
+	clock := mock.NewMock()
+	timers := make([]pkg.Timer, n)
+
+	for i := range timers {
+		timers[i] = clock.AfterFunc(time.Duration(i+1)*time.Second, func() {})
+	}
+
+	return clock, timers
+}
+
+func BenchmarkMock_AfterFunc(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(
+			fmt.Sprint(n), func(b *testing.B) {
+				clock, _ := newBenchmarkMock(n)
+
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					clock.AfterFunc(time.Duration(i%n)*time.Second, func() {}).Stop()
+				}
+			},
+		)
+	}
+}
+
+func BenchmarkTimer_Stop(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(
+			fmt.Sprint(n), func(b *testing.B) {
+				_, timers := newBenchmarkMock(n)
+
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					timer := timers[i%n]
+					timer.Stop()
+					timer.Reset(time.Duration(i%n+1) * time.Second)
+				}
+			},
+		)
+	}
+}
+
+func BenchmarkTimer_Reset(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(
+			fmt.Sprint(n), func(b *testing.B) {
+				_, timers := newBenchmarkMock(n)
+
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					timers[i%n].Reset(time.Duration(n-i%n) * time.Second)
+				}
+			},
+		)
+	}
+}
+
+func BenchmarkMock_Add(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(
+			fmt.Sprint(n), func(b *testing.B) {
+				clock, timers := newBenchmarkMock(n)
+
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					clock.Add(time.Second)
+					timers[i%n].Reset(time.Duration(n) * time.Second)
+				}
+			},
+		)
+	}
+}
+
+func BenchmarkMock_WaitForAllTimers(b *testing.B) {
+	for _, n := range benchmarkSizes[:2] {
+		b.Run(
+			fmt.Sprint(n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					clock, _ := newBenchmarkMock(n)
+					clock.WaitForAllTimers()
+				}
+			},
+		)
+	}
+}
+
+// Ensure that timers fire in chronological order whatever the order of their creation.
+func TestMock_TimersOrder(t *testing.T) {
+	var (
+		clock  = mock.NewMock()
+		timers = make(map[int]pkg.Timer)
+	)
+
+	for _, d := range []int{5, 1, 4, 2, 3, 6} {
+		timers[d] = clock.Timer(time.Duration(d) * time.Second)
+	}
+
+	timers[6].Stop()
+
+	for d := 1; d <= 6; d++ {
+		clock.Add(time.Second)
+
+		for other, timer := range timers {
+			select {
+			case <-timer.Chan():
+				if other != d {
+					t.Fatalf("timer %d fired at %ds", other, d)
+				}
+			default:
+				if other == d && d != 6 {
+					t.Fatalf("timer %d did not fire", d)
+				}
+			}
+		}
+	}
+
+	clock.BlockUntil(0)
+}
+
+// Ensure that timers due at the same instant fire in creation order.
+// The functions are executed in firing order with WithSyncAfterFunc.
+func TestMock_TimersOrder_SameInstant(t *testing.T) {
+	const num = 100
+
+	var (
+		clock = mock.NewMock(mock.WithSyncAfterFunc())
+		fired = make(chan int, num)
+	)
+
+	timers := make([]pkg.Timer, num)
+	for i := range timers {
+		i := i
+
+		timers[i] = clock.AfterFunc(time.Second, func() { fired <- i })
+	}
+
+	// A reset timer fires after the timers created before the reset.
+	timers[0].Reset(time.Second)
+
+	clock.Add(time.Second)
+
+	for i := 1; i <= num; i++ {
+		if got := <-fired; got != i%num {
+			t.Fatalf("expected timer %d, got: %d", i%num, got)
+		}
+	}
+}
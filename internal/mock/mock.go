@@ -1,12 +1,14 @@
 package mock
 
 import (
+	"container/heap"
 	"context"
+	"fmt"
 	"sort"
 	"sync"
 	"time"
 
-	"github.com/itbasis/go-clock/v2/internal"
+	"github.com/itbasis/go-clock/v2/internal/deadline"
 	"github.com/itbasis/go-clock/v2/pkg"
 )
 
@@ -17,14 +19,58 @@ type Mock struct {
 	// point to.
 	mu sync.Mutex
 
-	now    time.Time    // current time
-	timers clockTickers // tickers & timers
+	now           time.Time        // current time
+	mono          time.Duration    // monotonic clock reading
+	epochs        []epoch          // periods between the wall clock jumps done by Set, in order
+	loc           *time.Location   // location of the reported times, if set
+	backward      pkg.BackwardJump // policy for the timers on backward jumps
+	syncFunc      bool             // True if AfterFunc functions run in the goroutine moving the clock
+	tickerPolicy  pkg.TickerPolicy // policy of the tickers for ticks not received yet
+	settleTimeout time.Duration    // real time RunUntilIdle waits for the AfterFunc functions to settle
+	stackDepth    int              // number of stack frames captured when a timer is created
+	timers        clockTickers     // tickers & timers
+	tickers       int              // number of tickers in timers
+	unreceived    map[*Ticker]bool // tickers which may have a tick not received yet
+	seq           uint64           // sequence number of the last registered timer
+	blockers      []*blocker       // goroutines waiting in BlockUntil
+
+	running map[uint64]*callbacks // AfterFunc functions executing in their own goroutine, by m.seq when started
+	changed chan struct{}         // closed when the timers or the running functions change, if not nil
+}
+
+// receivePollInterval is the real time between the checks for the receive of a tick buffered in a channel.
+const receivePollInterval = time.Millisecond
+
+// epoch is a period of the mock clock between two wall clock jumps done by Set.
+type epoch struct {
+	mono time.Duration // monotonic clock reading at the start of the period
+	wall time.Time     // wall time at the start of the period
+}
+
+// callbacks counts the AfterFunc functions started while the same timer was the last registered one.
+type callbacks struct {
+	running int // functions which have not returned yet
+	timers  int // pending timers registered after the functions started
 }
 
 // NewMock returns an instance of a mock clock.
-// The current time of the mock clock on initialization is the Unix epoch.
-func NewMock() *Mock {
-	return &Mock{now: time.Unix(0, 0)}
+// The current time of the mock clock on initialization is the Unix epoch, unless set by options.
+func NewMock(opts ...Option) *Mock {
+	m := &Mock{now: time.Unix(0, 0), settleTimeout: defaultSettleTimeout, stackDepth: defaultStackDepth}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	m.now = m.in(m.now)
+	m.epochs = []epoch{{mono: m.mono, wall: m.now}}
+
+	return m
+}
+
+// NewMockAt returns an instance of a mock clock with the current time set to t.
+func NewMockAt(t time.Time, opts ...Option) *Mock {
+	return NewMock(append([]Option{WithStartTime(t)}, opts...)...)
 }
 
 // After waits for the duration to elapse and then sends the current time on the returned channel.
@@ -32,9 +78,16 @@ func (m *Mock) After(d time.Duration) <-chan time.Time {
 	return m.Timer(d).Chan()
 }
 
-// AfterFunc waits for the duration to elapse and then executes a function in its own goroutine.
+// AfterFunc waits for the duration to elapse and then executes a function in its own goroutine,
+// or in the goroutine moving the clock if the mock clock was created with WithSyncAfterFunc.
 // A Timer is returned that can be stopped.
 func (m *Mock) AfterFunc(duration time.Duration, f func()) pkg.Timer {
+	return m.afterFunc(duration, f, m.syncFunc)
+}
+
+// afterFunc registers a timer executing f.
+// If inline is true, f is executed by the goroutine advancing the clock, before the advance continues.
+func (m *Mock) afterFunc(duration time.Duration, f func(), inline bool) *Timer {
 	m.mu.Lock()
 
 	defer m.mu.Unlock()
@@ -42,13 +95,15 @@ func (m *Mock) AfterFunc(duration time.Duration, f func()) pkg.Timer {
 	ch := make(chan time.Time, 1)
 
 	timer := NewTimer(ch, f, m, duration)
+	timer.inline = inline
 
-	m.timers = append(m.timers, timer)
+	m.addClockTimer(timer)
 
 	return timer
 }
 
 // Now returns the current wall time on the mock clock.
+// Unlike Monotonic, it moves with the wall clock jumps done by Set.
 func (m *Mock) Now() time.Time {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -56,14 +111,62 @@ func (m *Mock) Now() time.Time {
 	return m.now
 }
 
-// Since returns time since `t` using the mock clock's wall time.
+// Monotonic returns the monotonic clock reading of the mock clock.
+// It only moves forward with Add, while Set only changes the wall clock.
+func (m *Mock) Monotonic() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.mono
+}
+
+// Since returns the time elapsed since t. Like time.Since, it is measured with the monotonic clock
+// reading for the times returned by Now, so the wall clock jumps done by Set are not counted.
+// See monotonicAt for the times which are not returned by Now.
 func (m *Mock) Since(t time.Time) time.Duration {
-	return m.Now().Sub(t)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if mono, ok := m.monotonicAt(t, false); ok {
+		return m.mono - mono
+	}
+
+	return m.now.Sub(t)
 }
 
-// Until returns time until `t` using the mock clock's wall time.
+// Until returns the duration until t. Like time.Until, it is measured with the monotonic clock
+// reading for the times returned by Now and the times after the current one, so the wall clock jumps
+// done by Set are not counted. See monotonicAt for the other times.
 func (m *Mock) Until(t time.Time) time.Duration {
-	return t.Sub(m.Now())
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if mono, ok := m.monotonicAt(t, true); ok {
+		return mono - m.mono
+	}
+
+	return t.Sub(m.now)
+}
+
+// monotonicAt returns the monotonic clock reading at which the mock clock had the wall time t.
+// A time after the start of the current epoch is taken in this epoch, including a time after the current one
+// if future is true. Otherwise, t is taken at its latest occurrence in the previous epochs: a wall time
+// may have occurred more than once after a backward jump. It returns false if t never occurred,
+// and the wall clock is then used instead. m.mu MUST be held when this method is called.
+func (m *Mock) monotonicAt(t time.Time, future bool) (time.Duration, bool) {
+	end := m.mono
+
+	for i := len(m.epochs) - 1; i >= 0; i-- {
+		e := m.epochs[i]
+
+		if d := t.Sub(e.wall); d >= 0 && (e.mono+d <= end || future && i == len(m.epochs)-1) {
+			return e.mono + d, true
+		}
+
+		end = e.mono
+	}
+
+	return 0, false
 }
 
 // Sleep pauses the goroutine for the given duration on the mock clock.
@@ -77,16 +180,25 @@ func (m *Mock) Sleep(d time.Duration) {
 func (m *Mock) Tick(d time.Duration) <-chan time.Time { return m.Ticker(d).Chan() }
 
 // Ticker creates a new instance of Ticker.
+// The duration must be greater than zero; if not, Ticker will panic.
 func (m *Mock) Ticker(duration time.Duration) pkg.Ticker {
+	if duration <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+
 	m.mu.Lock()
 
 	defer m.mu.Unlock()
 
-	ch := make(chan time.Time, 1)
+	// Ticks are only sent to a receiver with the policies which never drop them.
+	ch := make(chan time.Time)
+	if m.tickerPolicy == pkg.TickerDrop {
+		ch = make(chan time.Time, 1)
+	}
 
 	ticker := NewTicker(ch, m, duration)
 
-	m.timers = append(m.timers, ticker)
+	m.addClockTimer(ticker)
 
 	return ticker
 }
@@ -98,10 +210,10 @@ func (m *Mock) Timer(duration time.Duration) pkg.Timer {
 
 	timer := NewTimer(ch, nil, m, duration)
 
-	m.timers = append(m.timers, timer)
+	m.addClockTimer(timer)
 	now := m.now
 	m.mu.Unlock()
-	m.runNextTimer(now)
+	m.runNextTimer(now, true)
 
 	return timer
 }
@@ -110,112 +222,524 @@ func (m *Mock) WithTimeout(parent context.Context, timeout time.Duration) (conte
 	return m.WithDeadline(parent, m.Now().Add(timeout))
 }
 
-func (m *Mock) WithDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
-	if cur, ok := parent.Deadline(); ok && cur.Before(deadline) {
-		// The current deadline is already sooner than the new one.
-		return context.WithCancel(parent)
-	}
-
-	ctx := &timerCtx{clock: m, parent: parent, deadline: deadline, done: make(chan struct{})}
-	propagateCancel(parent, ctx)
-
-	dur := m.Until(deadline)
+func (m *Mock) WithDeadline(parent context.Context, d time.Time) (context.Context, context.CancelFunc) {
+	return deadline.WithDeadline(m, m.deadlineFunc, parent, d)
+}
 
-	if dur <= 0 {
-		ctx.cancel(context.DeadlineExceeded) // deadline has already passed
+// deadlineFunc registers the timer of a context deadline.
+// The deadline is cancelled by the goroutine advancing the clock,
+// so the context is done by the time Add or Set returns.
+func (m *Mock) deadlineFunc(d time.Duration, f func()) pkg.Timer {
+	return m.InlineFunc(pkg.KindContextDeadline, 0, d, f)
+}
 
-		return ctx, func() {}
-	}
+// InlineFunc registers a timer executing f in the goroutine advancing the clock, before the advance continues.
+// The timer is reported with the kind and the period in snapshots and events; a timer of kind pkg.KindTicker
+// is not waited for by WaitForAllTimers, like a ticker. The offset and scaled clocks relay their timers and
+// tickers with it, so the values reach their channels by the time Add or Set returns.
+func (m *Mock) InlineFunc(kind pkg.TimerKind, period, d time.Duration, f func()) pkg.Timer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	ctx.Lock()
-	defer ctx.Unlock()
+	timer := NewTimer(make(chan time.Time, 1), f, m, d)
+	timer.inline = true
+	timer.kind = kind
+	timer.period = period
 
-	if ctx.err == nil {
-		ctx.timer = m.AfterFunc(
-			dur, func() {
-				ctx.cancel(context.DeadlineExceeded)
-			},
-		)
-	}
+	m.addClockTimer(timer)
 
-	return ctx, func() { ctx.cancel(context.Canceled) }
+	return timer
 }
 
 // Add moves the current time of the mock clock forward by the specified duration.
+// Both the wall clock and the monotonic clock reading move forward.
 // This should only be called from a single goroutine at a time.
 func (m *Mock) Add(duration time.Duration) {
+	m.add(duration, nil)
+}
+
+// AddAndReport is like Add but returns a report of the timers fired during the move.
+func (m *Mock) AddAndReport(duration time.Duration) pkg.AdvanceReport {
+	var report pkg.AdvanceReport
+
+	m.add(duration, &report)
+
+	return report
+}
+
+// add moves the current time forward by the specified duration, recording the fired timers in report if not nil.
+func (m *Mock) add(duration time.Duration, report *pkg.AdvanceReport) {
 	// Calculate the final current time.
 	m.mu.Lock()
 	t := m.now.Add(duration)
 	m.mu.Unlock()
 
+	m.advance(t, true, report)
+}
+
+// Set sets the wall time of the mock clock to a specific one, like a step of the system clock.
+// The monotonic clock reading is unchanged. Timers due before the new time are executed,
+// unless they follow the monotonic clock with pkg.BackwardJumpWallOnly.
+// If the new time is before the current one, the pending timers are handled according to
+// the policy set by WithBackwardJump.
+// It panics with the error of TrySet.
+// This should only be called from a single goroutine at a time.
+func (m *Mock) Set(t time.Time) {
+	if err := m.set(t, nil); err != nil {
+		panic(err)
+	}
+}
+
+// TrySet is like Set but returns an error wrapping pkg.ErrBackwardJump, leaving the clock unchanged,
+// if t is before the current time and the pkg.BackwardJumpReject policy is in effect.
+func (m *Mock) TrySet(t time.Time) error {
+	return m.set(t, nil)
+}
+
+// SetAndReport is like Set but returns a report of the timers fired during the move.
+func (m *Mock) SetAndReport(t time.Time) pkg.AdvanceReport {
+	var report pkg.AdvanceReport
+
+	if err := m.set(t, &report); err != nil {
+		panic(err)
+	}
+
+	return report
+}
+
+// set sets the wall time of the mock clock, recording the fired timers in report if not nil.
+// It returns an error if the backward jump policy rejects the move.
+func (m *Mock) set(t time.Time, report *pkg.AdvanceReport) error {
+	t = t.Round(0)
+
+	m.mu.Lock()
+	switch jump := t.Sub(m.now); {
+	case jump < 0:
+		if m.backward == pkg.BackwardJumpReject {
+			err := fmt.Errorf("%w: from %s to %s", pkg.ErrBackwardJump, m.now, m.in(t))
+			m.mu.Unlock()
+
+			return err
+		}
+
+		m.jumpBackward(jump)
+	case jump > 0 && m.backward == pkg.BackwardJumpWallOnly:
+		// The timers follow the monotonic clock, which does not move.
+		m.shiftTimers(jump)
+	}
+
+	m.epochs = append(m.epochs, epoch{mono: m.mono, wall: m.in(t)})
+	m.mu.Unlock()
+
+	m.advance(t, false, report)
+
+	return nil
+}
+
+// jumpBackward updates the pending timers for a backward jump of the wall clock.
+// m.mu MUST be held when this method is called.
+func (m *Mock) jumpBackward(jump time.Duration) {
+	switch m.backward {
+	case pkg.BackwardJumpWallOnly, pkg.BackwardJumpRebase:
+		// Both keep the remaining durations of the timers on backward jumps,
+		// the elapsed part of their durations is not thrown away.
+		m.shiftTimers(jump)
+	}
+}
+
+// shiftTimers moves the next tick times of the pending timers along with a wall clock jump,
+// so they keep their remaining durations. m.mu MUST be held when this method is called.
+func (m *Mock) shiftTimers(jump time.Duration) {
+	// Every timer moves by the same duration, so the heap order is unchanged.
+	for _, timer := range m.timers {
+		timer.setNext(timer.Next().Add(jump))
+	}
+}
+
+// advance executes the timers due before t and moves the current time to t.
+// The monotonic clock reading moves forward with the current time if elapsed is true.
+// The fired timers are recorded in report if not nil.
+func (m *Mock) advance(t time.Time, elapsed bool, report *pkg.AdvanceReport) {
+	if report != nil {
+		report.From = m.Now()
+	}
+
 	// Continue to execute timers until there are no more before the new time.
 	for {
-		if !m.runNextTimer(t) {
+		event, ok := m.runNextTimer(t, elapsed)
+		if !ok {
 			break
 		}
+
+		if report != nil {
+			report.Events = append(report.Events, event)
+		}
 	}
 
 	// Ensure that we end with the new time.
 	m.mu.Lock()
-	m.now = t
+	m.moveTo(t, elapsed)
+
+	if report != nil {
+		report.To = m.now
+	}
+	m.mu.Unlock()
+}
+
+// Step moves the current time forward to the next pending timer and fires it.
+// It returns the event of the timer, or false if no timer is pending.
+func (m *Mock) Step() (pkg.Event, bool) {
+	m.mu.Lock()
+
+	if len(m.timers) == 0 {
+		m.mu.Unlock()
+
+		return pkg.Event{}, false
+	}
+
+	// An overdue timer fires at the current time.
+	next := m.timers[0].Next()
+	if next.Before(m.now) {
+		next = m.now
+	}
 	m.mu.Unlock()
 
-	// Give a small buffer to make sure that other goroutines get handled.
-	internal.Gosched()
+	return m.runNextTimer(next, true)
 }
 
-// Set sets the current time of the mock clock to a specific one.
-// This should only be called from a single goroutine at a time.
-func (m *Mock) Set(time time.Time) {
-	// Continue to execute timers until there are no more before the new time.
-	for {
-		if !m.runNextTimer(time) {
+// StepN calls Step n times, or until no timer is pending, and returns the fired events.
+func (m *Mock) StepN(n int) []pkg.Event {
+	events := make([]pkg.Event, 0, n)
+
+	for i := 0; i < n; i++ {
+		event, ok := m.Step()
+		if !ok {
 			break
 		}
+
+		events = append(events, event)
 	}
 
-	// Ensure that we end with the new time.
+	return events
+}
+
+// RunUntil calls Step until done returns true for a fired event, and returns the fired events.
+// It returns pkg.ErrNoPendingTimers if no timer is pending before done returns true.
+// It never returns while a ticker is running if done never returns true.
+func (m *Mock) RunUntil(done func(event pkg.Event) bool) ([]pkg.Event, error) {
+	var events []pkg.Event
+
+	for {
+		event, ok := m.Step()
+		if !ok {
+			return events, pkg.ErrNoPendingTimers
+		}
+
+		events = append(events, event)
+
+		if done(event) {
+			return events, nil
+		}
+	}
+}
+
+// PendingTimers returns snapshots of the timers, tickers and context deadlines
+// pending on the mock clock, in firing order.
+func (m *Mock) PendingTimers() []pkg.TimerInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	timers := make(clockTickers, len(m.timers))
+	copy(timers, m.timers)
+	sort.Slice(timers, func(i, j int) bool { return lessClockTickers(timers[i], timers[j]) })
+
+	infos := make([]pkg.TimerInfo, len(timers))
+	for i, t := range timers {
+		infos[i] = t.info()
+	}
+
+	return infos
+}
+
+// NextDeadline returns the next fire time of the pending timers.
+// It returns false if no timer is pending.
+func (m *Mock) NextDeadline() (time.Time, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.timers) == 0 {
+		return time.Time{}, false
+	}
+
+	return m.timers[0].Next(), true
+}
+
+// BlockUntil blocks until exactly n timers, tickers, sleeps and context deadlines are registered
+// on the mock clock. It counts registrations, not goroutines: the mock clock cannot observe a receive
+// on a channel, so a timer counts from its creation until it fires or is stopped, and a ticker until
+// it is stopped, whether or not a goroutine waits on them.
+func (m *Mock) BlockUntil(n int) {
+	_ = m.BlockUntilContext(context.Background(), n)
+}
+
+// BlockUntilContext blocks until exactly n timers are registered on the mock clock, like BlockUntil,
+// or the context is done.
+// It returns the context error if the context is done first.
+func (m *Mock) BlockUntilContext(ctx context.Context, n int) error {
 	m.mu.Lock()
-	m.now = time
+
+	if len(m.timers) == n {
+		m.mu.Unlock()
+
+		return nil
+	}
+
+	b := &blocker{count: n, ch: make(chan struct{})}
+	m.blockers = append(m.blockers, b)
 	m.mu.Unlock()
 
-	// Give a small buffer to make sure that other goroutines get handled.
-	internal.Gosched()
+	select {
+	case <-b.ch:
+		return nil
+	case <-ctx.Done():
+		m.mu.Lock()
+		m.removeBlocker(b)
+		m.mu.Unlock()
+
+		return ctx.Err() //nolint:wrapcheck
+	}
 }
 
-// WaitForAllTimers sets the clock until all timers are expired
+// WaitForAllTimers moves the clock forward, firing the pending timers in chronological order,
+// until only tickers are pending. It returns the current time.
 func (m *Mock) WaitForAllTimers() time.Time {
-	// Continue to execute timers until there are no more
+	_ = m.RunUntilIdle(0, 0)
+
+	return m.Now()
+}
+
+// RunUntilIdle moves the clock forward, firing the pending timers in chronological order,
+// until only tickers are pending. Tickers fire as the clock moves, but are not waited for.
+// Before checking the pending timers, it waits until the functions passed to AfterFunc are settled,
+// so the timers they create are fired too. See settled.
+//
+// It returns a *pkg.IdleError listing the pending timers if the next timer is due more than maxVirtual
+// after the current time, or maxEvents timers have fired before the clock is idle.
+// A bound less than or equal to zero is unlimited. It also returns a *pkg.IdleError if the functions
+// are not settled within the real time set by WithSettleTimeout, for instance while a function
+// waits for something else than the clock.
+func (m *Mock) RunUntilIdle(maxVirtual time.Duration, maxEvents int) error {
+	m.mu.Lock()
+	limit := m.now.Add(maxVirtual)
+	m.mu.Unlock()
+
+	for events := 0; ; events++ {
+		if err := m.settle(); err != nil {
+			return err
+		}
+
+		m.mu.Lock()
+
+		if !m.hasTimers() {
+			m.mu.Unlock()
+
+			return nil
+		}
+
+		var reason string
+
+		switch next := m.timers[0].Next(); {
+		case maxEvents > 0 && events >= maxEvents:
+			reason = fmt.Sprintf("%d events fired", events)
+		case maxVirtual > 0 && next.After(limit):
+			reason = fmt.Sprintf("next timer due after %s", maxVirtual)
+		}
+
+		if reason != "" {
+			m.mu.Unlock()
+
+			return &pkg.IdleError{Reason: reason, Pending: m.PendingTimers()}
+		}
+		m.mu.Unlock()
+
+		m.Step()
+	}
+}
+
+// settle waits until the AfterFunc functions are settled, for at most the settle timeout of real time.
+// It returns a *pkg.IdleError if they are not settled in time.
+func (m *Mock) settle() error {
+	ctx := context.Background()
+
+	if m.settleTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, m.settleTimeout)
+		defer cancel()
+	}
+
+	if err := m.waitFor(ctx, m.settled); err != nil {
+		return &pkg.IdleError{
+			Reason:  fmt.Sprintf("AfterFunc functions not settled after %s", m.settleTimeout),
+			Pending: m.PendingTimers(),
+		}
+	}
+
+	return nil
+}
+
+// watchTicker records that the ticker may have a tick not received yet. m.mu MUST be held
+// when this method is called.
+func (m *Mock) watchTicker(t *Ticker) {
+	if m.unreceived == nil {
+		m.unreceived = make(map[*Ticker]bool)
+	}
+
+	m.unreceived[t] = true
+}
+
+// waiters returns the number of pending timers goroutines may be parked on: the timers, sleeps, AfterFunc
+// functions and context deadlines, and the tickers whose previous tick has been received. It also reports
+// whether a tick not received yet is buffered in the channel of a ticker with TickerDrop, whose receive
+// cannot be observed. m.mu MUST be held when this method is called.
+func (m *Mock) waiters() (n int, buffered bool) {
+	n = len(m.timers)
+
+	for t := range m.unreceived {
+		if !t.unreceived() {
+			delete(m.unreceived, t)
+
+			continue
+		}
+
+		n--
+		buffered = buffered || len(t.c) > 0
+	}
+
+	return n, buffered
+}
+
+// hasTimers reports whether timers other than tickers are pending.
+// m.mu MUST be held when this method is called.
+func (m *Mock) hasTimers() bool { return len(m.timers) > m.tickers }
+
+// settled reports whether every AfterFunc function running in its own goroutine has returned
+// or is parked on the clock. A function is parked once it waits for a timer, ticker or sleep of the clock,
+// which it must have registered after it started: each running function is matched with a distinct
+// pending timer registered after the function started. m.mu MUST be held when this method is called.
+func (m *Mock) settled() bool {
+	starts := make([]uint64, 0, len(m.running))
+
+	for start := range m.running {
+		starts = append(starts, start)
+	}
+
+	// The timers registered after a function started were registered after the functions started
+	// before it, so matching the functions from the latest one only needs counting.
+	sort.Slice(starts, func(i, j int) bool { return starts[i] > starts[j] })
+
+	running := 0
+
+	for _, start := range starts {
+		c := m.running[start]
+
+		running += c.running
+		if c.timers < running {
+			return false
+		}
+	}
+
+	return true
+}
+
+// waitFor blocks until cond returns true, evaluating it with m.mu held whenever the timers,
+// the running AfterFunc functions or the ticks not received yet change. A tick buffered in the channel
+// of a ticker is checked for every receivePollInterval of real time instead, since its receive cannot
+// be observed. It returns the context error if the context is done first.
+func (m *Mock) waitFor(ctx context.Context, cond func() bool) error {
 	for {
 		m.mu.Lock()
-		if len(m.timers) == 0 {
+
+		if cond() {
 			m.mu.Unlock()
 
-			return m.Now()
+			return nil
+		}
+
+		if m.changed == nil {
+			m.changed = make(chan struct{})
 		}
 
-		sort.Sort(m.timers)
-		next := m.timers[len(m.timers)-1].Next()
+		changed := m.changed
+		_, buffered := m.waiters()
 		m.mu.Unlock()
-		m.Set(next)
+
+		if err := m.waitChange(ctx, changed, buffered); err != nil {
+			return err
+		}
 	}
 }
 
+// waitChange waits until changed is closed, or for receivePollInterval of real time if poll is true.
+// It returns the context error if the context is done first.
+func (m *Mock) waitChange(ctx context.Context, changed chan struct{}, poll bool) error {
+	var timeout <-chan time.Time
+
+	if poll {
+		timer := time.NewTimer(receivePollInterval)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	select {
+	case <-changed:
+	case <-timeout:
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// notifyChanged wakes up the goroutines in waitFor. m.mu MUST be held when this method is called.
+func (m *Mock) notifyChanged() {
+	if m.changed != nil {
+		close(m.changed)
+		m.changed = nil
+	}
+}
+
+// moveTo sets the current time of the mock clock. If elapsed is true, the monotonic
+// clock reading moves forward by the elapsed time. m.mu MUST be held when this method is called.
+func (m *Mock) moveTo(t time.Time, elapsed bool) {
+	if d := t.Sub(m.now); elapsed && d > 0 {
+		m.mono += d
+	}
+
+	m.now = m.in(t)
+}
+
+// in converts t to the location of the mock clock, if set.
+func (m *Mock) in(t time.Time) time.Time {
+	if m.loc == nil {
+		return t
+	}
+
+	return t.In(m.loc)
+}
+
 // runNextTimer executes the next timer in chronological order and moves the
 // current time to the timer's next tick time. The next time is not executed if
-// its next time is after the max time. Returns true if a timer was executed.
-func (m *Mock) runNextTimer(max time.Time) bool {
+// its next time is after the max time. Returns the event of the timer and true if a timer was executed.
+func (m *Mock) runNextTimer(max time.Time, elapsed bool) (pkg.Event, bool) {
 	m.mu.Lock()
 
-	// Sort timers by time.
-	sort.Sort(m.timers)
-
 	// If we have no more timers then exit.
 	if len(m.timers) == 0 {
 		m.mu.Unlock()
 
-		return false
+		return pkg.Event{}, false
 	}
 
 	// Retrieve next timer. Exit if next tick is after new time.
@@ -223,32 +747,168 @@ func (m *Mock) runNextTimer(max time.Time) bool {
 	if t.Next().After(max) {
 		m.mu.Unlock()
 
-		return false
+		return pkg.Event{}, false
 	}
 
-	// Move "now" forward and unlock clock.
-	m.now = t.Next()
-	now := m.now
+	// Move "now" forward, unless the timer is overdue, and fire the timer before unlocking the clock,
+	// so a concurrent Stop or Reset either prevents it or happens after it.
+	if next := t.Next(); next.After(m.now) {
+		m.moveTo(next, elapsed)
+	}
+
+	event, deliver := t.fire(m.now)
 	m.mu.Unlock()
 
-	// Execute timer.
-	t.Tick(now)
+	// Complete the delivery of the timer.
+	if deliver != nil {
+		deliver(&event)
+	}
 
-	return true
+	return event, true
 }
 
 // removeClockTimer removes a timer from m.timers. m.mu MUST be held
 // when this method is called.
 func (m *Mock) removeClockTimer(t clockTicker) {
-	for i, timer := range m.timers {
-		if timer == t {
-			copy(m.timers[i:], m.timers[i+1:])
-			m.timers[len(m.timers)-1] = nil
-			m.timers = m.timers[:len(m.timers)-1]
+	m.unschedule(t)
+	m.notifyBlockers()
+	m.notifyChanged()
+}
 
-			break
+// unschedule removes a timer from the heap and the counts of m.timers, if scheduled.
+// m.mu MUST be held when this method is called.
+func (m *Mock) unschedule(t clockTicker) {
+	s := t.slot()
+	if !s.scheduled() {
+		return
+	}
+
+	heap.Remove(&m.timers, s.index)
+
+	if isTicker(t) {
+		m.tickers--
+	}
+
+	for start, c := range m.running {
+		if s.seq > start {
+			c.timers--
 		}
 	}
+}
+
+// isTicker reports whether t is a ticker, including the timers relaying the ticks of a wrapping clock.
+func isTicker(t clockTicker) bool {
+	switch t := t.(type) {
+	case *Ticker:
+		return true
+	case *Timer:
+		return t.kind == pkg.KindTicker
+	default:
+		return false
+	}
+}
 
-	sort.Sort(m.timers)
+// addClockTimer registers a timer in m.timers. m.mu MUST be held
+// when this method is called.
+func (m *Mock) addClockTimer(t clockTicker) {
+	s := t.slot()
+
+	m.seq++
+	s.seq = m.seq
+
+	heap.Push(&m.timers, t)
+
+	if isTicker(t) {
+		m.tickers++
+	}
+
+	// The timer is registered after every running function started.
+	for _, c := range m.running {
+		c.timers++
+	}
+
+	m.notifyBlockers()
+	m.notifyChanged()
+}
+
+// resetClockTimer registers a timer in m.timers as if it was created again,
+// so it fires after the timers already due at the same time. m.mu MUST be held
+// when this method is called.
+func (m *Mock) resetClockTimer(t clockTicker) {
+	m.unschedule(t)
+	m.addClockTimer(t)
+}
+
+// fixClockTimer restores the position of a timer in m.timers after its next tick time changed.
+// m.mu MUST be held when this method is called.
+func (m *Mock) fixClockTimer(t clockTicker) {
+	if s := t.slot(); s.scheduled() {
+		heap.Fix(&m.timers, s.index)
+	}
+}
+
+// startCallback executes an AfterFunc function in its own goroutine, like time.AfterFunc.
+// Functions due at the same instant are started in firing order, but run concurrently.
+// m.mu MUST be held when this method is called.
+func (m *Mock) startCallback(f func()) {
+	if m.running == nil {
+		m.running = make(map[uint64]*callbacks)
+	}
+
+	// The pending timers were all registered before the function started.
+	start := m.seq
+
+	c := m.running[start]
+	if c == nil {
+		c = &callbacks{}
+		m.running[start] = c
+	}
+
+	c.running++
+	m.notifyChanged()
+
+	go func() {
+		defer func() {
+			m.mu.Lock()
+
+			if c.running--; c.running == 0 {
+				delete(m.running, start)
+			}
+
+			m.notifyChanged()
+			m.mu.Unlock()
+		}()
+
+		f()
+	}()
+}
+
+// notifyBlockers releases the blockers waiting for the current number of registered timers.
+// m.mu MUST be held when this method is called.
+func (m *Mock) notifyBlockers() {
+	blockers := m.blockers[:0]
+
+	for _, b := range m.blockers {
+		if b.count == len(m.timers) {
+			close(b.ch)
+
+			continue
+		}
+
+		blockers = append(blockers, b)
+	}
+
+	m.blockers = blockers
+}
+
+// removeBlocker removes a blocker from m.blockers. m.mu MUST be held
+// when this method is called.
+func (m *Mock) removeBlocker(b *blocker) {
+	for i, blocker := range m.blockers {
+		if blocker == b {
+			m.blockers = append(m.blockers[:i], m.blockers[i+1:]...)
+
+			break
+		}
+	}
 }
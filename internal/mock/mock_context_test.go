@@ -40,6 +40,27 @@ func TestMock_WithDeadlineLaterThanCurrent(t *testing.T) {
 	}
 }
 
+// Ensure that WithDeadline registers its deadline when the current deadline is from another clock,
+// whose times do not compare with the ones of the mock clock.
+func TestMock_WithDeadlineOtherClock(t *testing.T) {
+	m := mock.NewMockAt(time.Now().Add(24 * time.Hour))
+
+	parent, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	// The real-time deadline of the parent is before the deadline, a day later.
+	ctx, _ := m.WithDeadline(parent, m.Now().Add(2*time.Hour))
+	if d, ok := ctx.Deadline(); !ok || !d.Equal(m.Now().Add(2*time.Hour)) {
+		t.Fatalf("Deadline() = %v, %v; want %v", d, ok, m.Now().Add(2*time.Hour))
+	}
+
+	m.Add(2 * time.Hour)
+
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Fatalf("Err() = %v, want %v", ctx.Err(), context.DeadlineExceeded)
+	}
+}
+
 // Ensure that WithDeadline cancel closes Done channel with context.Canceled error.
 func TestMock_WithDeadlineCancel(t *testing.T) {
 	m := mock.NewMock()
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/itbasis/go-clock/v2/internal"
 	"github.com/itbasis/go-clock/v2/internal/mock"
 )
 
@@ -14,17 +13,18 @@ func ExampleMock_After() {
 		count counter
 	)
 
-	ready := make(chan struct{})
+	done := make(chan struct{})
 	// Create a channel to execute after 10 mock seconds.
 	go func() {
 		ch := clock.After(10 * time.Second)
 
-		close(ready)
-
 		<-ch
 		count.incr()
+		close(done)
 	}()
-	<-ready
+
+	// Wait until the channel is created.
+	clock.BlockUntil(1)
 
 	// Print the starting value.
 	fmt.Printf("%s: %d\n", clock.Now().UTC(), count.get())
@@ -35,6 +35,7 @@ func ExampleMock_After() {
 
 	// Move the clock forward 5 seconds to the tick time and check the value.
 	clock.Add(5 * time.Second)
+	<-done
 	fmt.Printf("%s: %d\n", clock.Now().UTC(), count.get())
 
 	// Output:
@@ -52,19 +53,21 @@ func ExampleMock_AfterFunc() {
 
 	count.incr()
 
+	done := make(chan struct{})
 	// Execute a function after 10 mock seconds.
 	clock.AfterFunc(
 		10*time.Second, func() {
 			count.incr()
+			close(done)
 		},
 	)
-	internal.Gosched()
 
 	// Print the starting value.
 	fmt.Printf("%s: %d\n", clock.Now().UTC(), count.get())
 
 	// Move the clock forward 10 seconds and print the new value.
 	clock.Add(10 * time.Second)
+	<-done
 	fmt.Printf("%s: %d\n", clock.Now().UTC(), count.get())
 
 	// Output:
@@ -79,19 +82,23 @@ func ExampleMock_Sleep() {
 		count counter
 	)
 
+	done := make(chan struct{})
 	// Execute a function after 10 mock seconds.
 	go func() {
 		clock.Sleep(10 * time.Second)
 		count.incr()
+		close(done)
 	}()
 
-	internal.Gosched()
+	// Wait until the goroutine is asleep.
+	clock.BlockUntil(1)
 
 	// Print the starting value.
 	fmt.Printf("%s: %d\n", clock.Now().UTC(), count.get())
 
 	// Move the clock forward 10 seconds and print the new value.
 	clock.Add(10 * time.Second)
+	<-done
 	fmt.Printf("%s: %d\n", clock.Now().UTC(), count.get())
 
 	// Output:
@@ -106,26 +113,22 @@ func ExampleMock_Ticker() {
 		count counter
 	)
 
-	ready := make(chan struct{})
 	// Increment count every mock second.
-	go func() {
-		ticker := clock.Ticker(1 * time.Second)
-
-		close(ready)
-
-		for {
+	ticker := clock.Ticker(1 * time.Second)
+	advance := func(n int) {
+		for i := 0; i < n; i++ {
+			clock.Add(1 * time.Second)
 			<-ticker.Chan()
 			count.incr()
 		}
-	}()
-	<-ready
+	}
 
 	// Move the clock forward 10 seconds and print the new value.
-	clock.Add(10 * time.Second)
+	advance(10)
 	fmt.Printf("Count is %d after 10 seconds\n", count.get())
 
 	// Move the clock forward 5 more seconds and print the new value.
-	clock.Add(5 * time.Second)
+	advance(5)
 	fmt.Printf("Count is %d after 15 seconds\n", count.get())
 
 	// Output:
@@ -139,20 +142,22 @@ func ExampleMock_Timer() {
 		count counter
 	)
 
-	ready := make(chan struct{})
+	done := make(chan struct{})
 	// Increment count after a mock second.
 	go func() {
 		timer := clock.Timer(1 * time.Second)
 
-		close(ready)
-
 		<-timer.Chan()
 		count.incr()
+		close(done)
 	}()
-	<-ready
+
+	// Wait until the timer is created.
+	clock.BlockUntil(1)
 
 	// Move the clock forward 10 seconds and print the new value.
 	clock.Add(10 * time.Second)
+	<-done
 	fmt.Printf("Count is %d after 10 seconds\n", count.get())
 
 	// Output:
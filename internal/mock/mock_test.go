@@ -1,14 +1,18 @@
 package mock_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
-	"github.com/itbasis/go-clock/v2/internal"
+	"github.com/itbasis/go-clock/v2/clocktest"
 	"github.com/itbasis/go-clock/v2/internal/mock"
+	"github.com/itbasis/go-clock/v2/pkg"
 )
 
 // Counter is an atomic uint32 that can be incremented easily.  It's
@@ -25,34 +29,98 @@ func (c *counter) get() uint32 {
 	return atomic.LoadUint32(&c.count)
 }
 
-// Ensure that the mock's After channel sends at the correct time.
-func TestMock_After(t *testing.T) {
+// expectTick fails the test if no value is ready on the channel.
+func expectTick(t *testing.T, ch <-chan time.Time) {
+	t.Helper()
+
+	select {
+	case <-ch:
+	default:
+		t.Fatal("too late")
+	}
+}
+
+// expectNoTick fails the test if a value is ready on the channel.
+func expectNoTick(t *testing.T, ch <-chan time.Time) {
+	t.Helper()
+
+	select {
+	case <-ch:
+		t.Fatal("too early")
+	default:
+	}
+}
+
+// expectDone fails the test if the channel is not closed within a second of real time.
+func expectDone(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("too late")
+	}
+}
+
+// expectNotDone fails the test if the channel is already closed.
+func expectNotDone(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-done:
+		t.Fatal("too early")
+	default:
+	}
+}
+
+// countTicks moves the clock forward by d while a concurrent reader receives the ticks
+// of the channel returned by newTick, and returns the number of ticks received.
+// The reader creates the ticker, and the clock only moves once it is registered.
+func countTicks(clock *mock.Mock, newTick func() <-chan time.Time, d time.Duration) int {
 	var (
-		ok    int32
-		clock = mock.NewMock()
+		n    int
+		stop = make(chan struct{})
+		done = make(chan struct{})
 	)
 
+	go func() {
+		defer close(done)
+
+		tick := newTick()
+
+		for {
+			select {
+			case <-tick:
+				n++
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	clock.BlockUntil(1)
+	clock.Add(d)
+
+	close(stop)
+	<-done
+
+	return n
+}
+
+// Ensure that the mock's After channel sends at the correct time.
+func TestMock_After(t *testing.T) {
+	clock := mock.NewMock()
+
 	// Create a channel to execute after 10 mock seconds.
 	ch := clock.After(10 * time.Second)
 
-	go func(ch <-chan time.Time) {
-		<-ch
-		atomic.StoreInt32(&ok, 1)
-	}(ch)
-
 	// Move clock forward to just before the time.
 	clock.Add(9 * time.Second)
-
-	if atomic.LoadInt32(&ok) == 1 {
-		t.Fatal("too early")
-	}
+	expectNoTick(t, ch)
 
 	// Move clock forward to the after channel's time.
 	clock.Add(1 * time.Second)
-
-	if atomic.LoadInt32(&ok) == 0 {
-		t.Fatal("too late")
-	}
+	expectTick(t, ch)
 }
 
 // Ensure that the mock's After channel doesn't block on write.
@@ -76,30 +144,24 @@ func TestMock_UnusedAfter(t *testing.T) {
 // Ensure that the mock's AfterFunc executes at the correct time.
 func TestMock_AfterFunc(t *testing.T) {
 	var (
-		ok    int32
+		done  = make(chan struct{})
 		clock = mock.NewMock()
 	)
 
 	// Execute function after duration.
 	clock.AfterFunc(
 		10*time.Second, func() {
-			atomic.StoreInt32(&ok, 1)
+			close(done)
 		},
 	)
 
 	// Move clock forward to just before the time.
 	clock.Add(9 * time.Second)
-
-	if atomic.LoadInt32(&ok) == 1 {
-		t.Fatal("too early")
-	}
+	expectNotDone(t, done)
 
 	// Move clock forward to the after channel's time.
 	clock.Add(1 * time.Second)
-
-	if atomic.LoadInt32(&ok) == 0 {
-		t.Fatal("too late")
-	}
+	expectDone(t, done)
 }
 
 // Ensure that the mock's AfterFunc doesn't execute if stopped.
@@ -112,12 +174,81 @@ func TestMock_AfterFunc_Stop(t *testing.T) {
 		},
 	)
 
-	internal.Gosched()
-
 	// Stop timer & move clock forward.
 	timer.Stop()
 	clock.Add(10 * time.Second)
-	internal.Gosched()
+}
+
+// Ensure that an AfterFunc function waiting for another one does not hold it back.
+func TestMock_AfterFunc_Blocking(t *testing.T) {
+	var (
+		clock  = mock.NewMock()
+		second = make(chan struct{})
+		done   = make(chan struct{})
+	)
+
+	clock.AfterFunc(
+		time.Second, func() {
+			<-second
+			close(done)
+		},
+	)
+	clock.AfterFunc(time.Second, func() { close(second) })
+
+	clock.Add(time.Second)
+	expectDone(t, done)
+}
+
+// Ensure that AfterFunc functions are done when Add returns with WithSyncAfterFunc,
+// including the functions of the timers they create within the same window.
+func TestMock_AfterFunc_Sync(t *testing.T) {
+	var (
+		clock = mock.NewMock(mock.WithSyncAfterFunc())
+		fired []time.Duration
+	)
+
+	record := func() { fired = append(fired, clock.Since(time.Unix(0, 0))) }
+
+	clock.AfterFunc(
+		2*time.Second, func() {
+			record()
+			clock.AfterFunc(3*time.Second, record)
+			clock.AfterFunc(10*time.Second, record)
+		},
+	)
+	clock.AfterFunc(4*time.Second, record)
+
+	clock.Add(5 * time.Second)
+
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second}
+	if !reflect.DeepEqual(fired, want) {
+		t.Fatalf("fired at %v, want %v", fired, want)
+	}
+
+	if n := len(clock.PendingTimers()); n != 1 {
+		t.Fatalf("%d pending timers, want 1", n)
+	}
+}
+
+// Ensure that a synchronous AfterFunc function can move the clock itself.
+func TestMock_AfterFunc_SyncReentrant(t *testing.T) {
+	var (
+		clock = mock.NewMock(mock.WithSyncAfterFunc())
+		done  bool
+	)
+
+	clock.AfterFunc(
+		time.Second, func() {
+			clock.AfterFunc(time.Second, func() { done = true })
+			clock.Add(time.Second)
+		},
+	)
+
+	clock.Add(time.Second)
+
+	if !done {
+		t.Fatal("nested function not executed")
+	}
 }
 
 // Ensure that the mock's current time can be changed.
@@ -165,98 +296,75 @@ func TestMock_Until(t *testing.T) {
 // Ensure that the mock can sleep for the correct time.
 func TestMock_Sleep(t *testing.T) {
 	var (
-		ok    int32
+		done  = make(chan struct{})
 		clock = mock.NewMock()
 	)
 
 	// Create a channel to execute after 10 mock seconds.
 	go func() {
 		clock.Sleep(10 * time.Second)
-		atomic.StoreInt32(&ok, 1)
+		close(done)
 	}()
 
-	internal.Gosched()
+	clock.BlockUntil(1)
 
 	// Move clock forward to just before the sleep duration.
 	clock.Add(9 * time.Second)
-
-	if atomic.LoadInt32(&ok) == 1 {
-		t.Fatal("too early")
-	}
+	expectNotDone(t, done)
 
 	// Move clock forward to after the sleep duration.
 	clock.Add(1 * time.Second)
-
-	if atomic.LoadInt32(&ok) == 0 {
-		t.Fatal("too late")
-	}
+	expectDone(t, done)
 }
 
 // Ensure that the mock's Tick channel sends at the correct time.
 func TestMock_Tick(t *testing.T) {
-	var (
-		n     int32
-		clock = mock.NewMock()
-	)
-
-	// Create a channel to increment every 10 seconds.
-	go func() {
-		tick := clock.Tick(10 * time.Second)
-
-		for {
-			<-tick
-			atomic.AddInt32(&n, 1)
-		}
-	}()
+	clock := mock.NewMock()
 
-	internal.Gosched()
+	// Create a channel to tick every 10 seconds.
+	tick := clock.Tick(10 * time.Second)
 
 	// Move clock forward to just before the first tick.
 	clock.Add(9 * time.Second)
-
-	if atomic.LoadInt32(&n) != 0 {
-		t.Fatalf("expected 0, got %d", n)
-	}
+	expectNoTick(t, tick)
 
 	// Move clock forward to the start of the first tick.
 	clock.Add(1 * time.Second)
+	expectTick(t, tick)
 
-	if atomic.LoadInt32(&n) != 1 {
-		t.Fatalf("expected 1, got %d", n)
+	// Move clock forward over several ticks.
+	for i := 0; i < 3; i++ {
+		clock.Add(10 * time.Second)
+		expectTick(t, tick)
 	}
 
-	// Move clock forward over several ticks.
-	clock.Add(30 * time.Second)
+	// Move clock forward over several ticks at once, with a concurrent reader.
+	// The ticks are not dropped since the clock waits for each one to be received.
+	clock = mock.NewMock(mock.WithTickerPolicy(pkg.TickerBlock))
 
-	if atomic.LoadInt32(&n) != 4 {
+	if n := countTicks(clock, func() <-chan time.Time { return clock.Tick(10 * time.Second) }, 40*time.Second); n != 4 {
 		t.Fatalf("expected 4, got %d", n)
 	}
 }
 
 // Ensure that the mock's Ticker channel sends at the correct time.
 func TestMock_Ticker(t *testing.T) {
-	var (
-		n     int32
-		clock = mock.NewMock()
-	)
-
-	// Create a channel to increment every microsecond.
-	go func() {
-		ticker := clock.Ticker(1 * time.Microsecond)
-
-		for {
-			<-ticker.Chan()
-			atomic.AddInt32(&n, 1)
-		}
-	}()
+	clock := mock.NewMock()
 
-	internal.Gosched()
+	// Create a channel to tick every microsecond.
+	ticker := clock.Ticker(1 * time.Microsecond)
 
 	// Move clock forward.
-	clock.Add(10 * time.Microsecond)
+	for i := 0; i < 10; i++ {
+		clock.Add(1 * time.Microsecond)
+		expectTick(t, ticker.Chan())
+	}
 
-	if atomic.LoadInt32(&n) != 10 {
-		t.Fatalf("unexpected: %d", n)
+	// Move clock forward over several ticks at once, with a concurrent reader.
+	clock = mock.NewMock(mock.WithTickerPolicy(pkg.TickerBlock))
+
+	if n := countTicks(clock, func() <-chan time.Time { return clock.Ticker(1 * time.Microsecond).Chan() }, 10*time.Microsecond); n != 10 {
+		t.Fatalf("expected 10, got %d", n)
 	}
 }
 
@@ -271,62 +379,34 @@ func TestMock_Ticker_Overflow(t *testing.T) {
 
 // Ensure that the mock's Ticker can be stopped.
 func TestMock_Ticker_Stop(t *testing.T) {
-	var (
-		n     int32
-		clock = mock.NewMock()
-	)
+	clock := mock.NewMock()
 
-	// Create a channel to increment every second.
+	// Create a channel to tick every second.
 	ticker := clock.Ticker(1 * time.Second)
 
-	go func() {
-		for {
-			<-ticker.Chan()
-			atomic.AddInt32(&n, 1)
-		}
-	}()
-
-	internal.Gosched()
-
 	// Move clock forward.
-	clock.Add(5 * time.Second)
-
-	if atomic.LoadInt32(&n) != 5 {
-		t.Fatalf("expected 5, got: %d", n)
+	for i := 0; i < 5; i++ {
+		clock.Add(1 * time.Second)
+		expectTick(t, ticker.Chan())
 	}
 
 	ticker.Stop()
 
 	// Move clock forward again.
 	clock.Add(5 * time.Second)
-
-	if atomic.LoadInt32(&n) != 5 {
-		t.Fatalf("still expected 5, got: %d", n)
-	}
+	expectNoTick(t, ticker.Chan())
 }
 
 func TestMock_Ticker_Reset(t *testing.T) {
-	var (
-		n     int32
-		clock = mock.NewMock()
-	)
+	clock := mock.NewMock()
 
 	ticker := clock.Ticker(5 * time.Second)
 	defer ticker.Stop()
 
-	go func() {
-		for {
-			<-ticker.Chan()
-			atomic.AddInt32(&n, 1)
-		}
-	}()
-	internal.Gosched()
-
 	// Move clock forward.
-	clock.Add(10 * time.Second)
-
-	if atomic.LoadInt32(&n) != 2 {
-		t.Fatalf("expected 2, got: %d", n)
+	for i := 0; i < 2; i++ {
+		clock.Add(5 * time.Second)
+		expectTick(t, ticker.Chan())
 	}
 
 	clock.Add(4 * time.Second)
@@ -334,66 +414,86 @@ func TestMock_Ticker_Reset(t *testing.T) {
 
 	// Advance the remaining second
 	clock.Add(1 * time.Second)
-
-	if atomic.LoadInt32(&n) != 2 {
-		t.Fatalf("expected 2, got: %d", n)
-	}
+	expectNoTick(t, ticker.Chan())
 
 	// Advance the remaining 4 seconds from the previous tick
 	clock.Add(4 * time.Second)
-
-	if atomic.LoadInt32(&n) != 3 {
-		t.Fatalf("expected 3, got: %d", n)
-	}
+	expectTick(t, ticker.Chan())
 }
 
 func TestMock_Ticker_Stop_Reset(t *testing.T) {
-	var (
-		n     int32
-		clock = mock.NewMock()
-	)
+	clock := mock.NewMock()
 
 	ticker := clock.Ticker(5 * time.Second)
 	defer ticker.Stop()
 
-	go func() {
-		for {
-			<-ticker.Chan()
-			atomic.AddInt32(&n, 1)
-		}
-	}()
-	internal.Gosched()
-
 	// Move clock forward.
-	clock.Add(10 * time.Second)
-
-	if atomic.LoadInt32(&n) != 2 {
-		t.Fatalf("expected 2, got: %d", n)
+	for i := 0; i < 2; i++ {
+		clock.Add(5 * time.Second)
+		expectTick(t, ticker.Chan())
 	}
 
 	ticker.Stop()
 
 	// Move clock forward again.
 	clock.Add(5 * time.Second)
-
-	if atomic.LoadInt32(&n) != 2 {
-		t.Fatalf("still expected 2, got: %d", n)
-	}
+	expectNoTick(t, ticker.Chan())
 
 	ticker.Reset(2 * time.Second)
 
 	// Advance the remaining 2 seconds
 	clock.Add(2 * time.Second)
-
-	if atomic.LoadInt32(&n) != 3 {
-		t.Fatalf("expected 3, got: %d", n)
-	}
+	expectTick(t, ticker.Chan())
 
 	// Advance another 2 seconds
 	clock.Add(2 * time.Second)
+	expectTick(t, ticker.Chan())
+}
+
+// Ensure that no value is received after Stop, and that a reset timer is not lost,
+// while another goroutine moves the clock to the expiry time of the timers.
+func TestMock_Timer_StopResetConcurrent(t *testing.T) {
+	const num = 200
+
+	for i := 0; i < 200; i++ {
+		var (
+			clock  = mock.NewMock()
+			timers = make([]pkg.Timer, num)
+			done   = make(chan struct{})
+		)
+
+		for j := range timers {
+			timers[j] = clock.Timer(time.Second)
+		}
+
+		go func() {
+			defer close(done)
+
+			clock.Add(time.Second)
+		}()
+
+		// Stop the even timers and reset the odd ones while they fire.
+		for j, timer := range timers {
+			if j%2 == 0 {
+				timer.Stop()
+			} else {
+				timer.Reset(time.Hour)
+			}
+		}
+
+		<-done
+
+		for j, timer := range timers {
+			select {
+			case <-timer.Chan():
+				t.Fatalf("timer %d: value received after Stop or Reset", j)
+			default:
+			}
+		}
 
-	if atomic.LoadInt32(&n) != 4 {
-		t.Fatalf("expected 4, got: %d", n)
+		if pending := clock.PendingTimers(); len(pending) != num/2 {
+			t.Fatalf("%d pending timers, want the %d reset timers", len(pending), num/2)
+		}
 	}
 }
 
@@ -402,29 +502,28 @@ func TestMock_Ticker_Multi(t *testing.T) {
 	var (
 		n     int32
 		clock = mock.NewMock()
+		a     = clock.Ticker(1 * time.Microsecond)
+		b     = clock.Ticker(3 * time.Microsecond)
 	)
 
-	go func() {
-		a := clock.Ticker(1 * time.Microsecond)
-		b := clock.Ticker(3 * time.Microsecond)
+	// Move clock forward.
+	for i := 0; i < 10; i++ {
+		clock.Add(1 * time.Microsecond)
 
-		for {
-			select {
-			case <-a.Chan():
-				atomic.AddInt32(&n, 1)
-			case <-b.Chan():
-				atomic.AddInt32(&n, 100)
-			}
+		select {
+		case <-a.Chan():
+			n++
+		default:
 		}
-	}()
 
-	internal.Gosched()
-
-	// Move clock forward.
-	clock.Add(10 * time.Microsecond)
-	internal.Gosched()
+		select {
+		case <-b.Chan():
+			n += 100
+		default:
+		}
+	}
 
-	if atomic.LoadInt32(&n) != 310 {
+	if n != 310 {
 		t.Fatalf("unexpected: %d", n)
 	}
 }
@@ -438,13 +537,17 @@ func TestMock_ReentrantDeadlock(t *testing.T) {
 		panic(fmt.Sprintf("timer should not have ticked: %v", v))
 	}()
 
+	stopped := make(chan struct{})
+
 	mockedClock.AfterFunc(
 		10*time.Second, func() {
 			timer20.Stop()
+			close(stopped)
 		},
 	)
 
 	mockedClock.Add(15 * time.Second)
+	expectDone(t, stopped)
 	mockedClock.Add(15 * time.Second)
 }
 
@@ -458,11 +561,8 @@ func TestMock_AddAfterFuncRace(t *testing.T) {
 	mockedClock := mock.NewMock()
 
 	var calls counter
-	defer func() {
-		if calls.get() == 0 {
-			t.Errorf("AfterFunc did not call the function")
-		}
-	}()
+
+	called := make(chan struct{})
 
 	wg.Add(1)
 
@@ -473,6 +573,7 @@ func TestMock_AddAfterFuncRace(t *testing.T) {
 		mockedClock.AfterFunc(
 			time.Millisecond, func() {
 				calls.incr()
+				close(called)
 			},
 		)
 	}()
@@ -489,25 +590,135 @@ func TestMock_AddAfterFuncRace(t *testing.T) {
 
 	close(start) // unblock the goroutines
 	wg.Wait()    // and wait for them
+
+	// The timer may have been registered after both advances.
+	mockedClock.Add(time.Millisecond)
+	expectDone(t, called)
+
+	if calls.get() != 1 {
+		t.Errorf("AfterFunc called the function %d times", calls.get())
+	}
 }
 
 func TestMock_AfterRace(t *testing.T) {
 	const num = 10
 
 	var (
-		mock     = mock.NewMock()
-		finished atomic.Int32
+		mock = mock.NewMock()
+		wg   sync.WaitGroup
 	)
 
 	for i := 0; i < num; i++ {
+		wg.Add(1)
+
 		go func() {
+			defer wg.Done()
+
 			<-mock.After(1 * time.Millisecond)
-			finished.Add(1)
 		}()
 	}
 
-	for finished.Load() < num {
-		mock.Add(time.Second)
-		internal.Gosched()
+	mock.BlockUntil(num)
+	mock.Add(time.Second)
+	wg.Wait()
+}
+
+// Ensure that BlockUntil waits for the expected number of waiters.
+func TestMock_BlockUntil(t *testing.T) {
+	var (
+		clock   = mock.NewMock()
+		blocked = make(chan struct{})
+	)
+
+	go func() {
+		clock.BlockUntil(2)
+		close(blocked)
+	}()
+
+	timer := clock.Timer(time.Second)
+	expectNotDone(t, blocked)
+
+	go clock.Sleep(time.Second)
+
+	expectDone(t, blocked)
+
+	// Waiters are released once they fire or are stopped.
+	timer.Stop()
+	clock.BlockUntil(1)
+	clock.Add(time.Second)
+	clock.BlockUntil(0)
+}
+
+// Ensure that BlockUntilContext gives up when the context is done.
+func TestMock_BlockUntilContext(t *testing.T) {
+	clock := mock.NewMock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := clock.BlockUntilContext(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+
+	if err := clock.BlockUntilContext(ctx, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure that the mock starts at the configured time.
+func TestMock_NewMockAt(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clock := mock.NewMockAt(start)
+
+	if now := clock.Now(); !now.Equal(start) {
+		t.Fatalf("expected %v, got: %v", start, now)
+	}
+
+	timer := clock.Timer(time.Second)
+	clock.Add(time.Second)
+
+	if v := <-timer.Chan(); !v.Equal(start.Add(time.Second)) {
+		t.Fatalf("expected %v, got: %v", start.Add(time.Second), v)
+	}
+}
+
+// Ensure that the mock reports times in the configured location.
+func TestMock_WithLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	clock := mock.NewMock(mock.WithStartTime(time.Unix(0, 0)), mock.WithLocation(loc))
+
+	if got := clock.Now().String(); got != "1970-01-01 03:00:00 +0300 UTC+3" {
+		t.Fatalf("unexpected time: %s", got)
+	}
+
+	clock.Set(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	if got := clock.Now().String(); got != "2024-01-01 03:00:00 +0300 UTC+3" {
+		t.Fatalf("unexpected time: %s", got)
+	}
+}
+
+// Ensure that the monotonic clock reading only moves forward.
+func TestMock_WithMonotonic(t *testing.T) {
+	clock := mock.NewMock(mock.WithMonotonic(time.Hour))
+
+	if mono := clock.Monotonic(); mono != time.Hour {
+		t.Fatalf("expected 1h, got: %v", mono)
+	}
+
+	clock.Add(time.Minute)
+	clock.Set(clock.Now().Add(-time.Hour))
+
+	if mono := clock.Monotonic(); mono != time.Hour+time.Minute {
+		t.Fatalf("expected 1h1m, got: %v", mono)
 	}
 }
+
+// Ensure that the mock clock follows the contract of pkg.Clock.
+func TestMock_Conformance(t *testing.T) {
+	clocktest.RunConformance(
+		t, func(*testing.T) clocktest.Subject {
+			return clocktest.Mock(mock.NewMock())
+		},
+	)
+}
@@ -0,0 +1,107 @@
+package mock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/mock"
+)
+
+// Ensure that elapsed time is measured with the monotonic clock reading across wall clock jumps.
+func TestMock_Monotonic_Set(t *testing.T) {
+	clock := mock.NewMockAt(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	start, mono := clock.Now(), clock.Monotonic()
+
+	clock.Add(10 * time.Second)
+	clock.Set(start.Add(-time.Hour))
+
+	if d := clock.Monotonic() - mono; d != 10*time.Second {
+		t.Errorf("elapsed = %v, want 10s", d)
+	}
+
+	// Since measures the elapsed time like time.Since, but the wall clock has jumped.
+	if d := clock.Since(start); d != 10*time.Second {
+		t.Errorf("Since() = %v, want 10s", d)
+	}
+
+	if d := clock.Now().Sub(start); d != -time.Hour {
+		t.Errorf("Now().Sub() = %v, want -1h", d)
+	}
+
+	if want := time.Date(2023, time.December, 31, 23, 0, 0, 0, time.UTC); !clock.Now().Equal(want) {
+		t.Errorf("Now() = %v, want %v", clock.Now(), want)
+	}
+}
+
+// Ensure that a forward wall clock jump does not move the monotonic clock reading.
+func TestMock_Monotonic_SetForward(t *testing.T) {
+	clock := mock.NewMock()
+
+	start := clock.Now()
+	timer := clock.Timer(time.Minute)
+
+	clock.Set(start.Add(time.Hour))
+
+	// Timers are executed as the wall clock passes their time.
+	select {
+	case <-timer.Chan():
+	default:
+		t.Fatal("timer did not fire")
+	}
+
+	if mono := clock.Monotonic(); mono != 0 {
+		t.Errorf("Monotonic() = %v, want 0", mono)
+	}
+
+	if d := clock.Since(start); d != 0 {
+		t.Errorf("Since() = %v, want 0", d)
+	}
+}
+
+// Ensure that Since and Until measure the times taken across a backward jump with the monotonic clock reading.
+func TestMock_Monotonic_SinceUntil(t *testing.T) {
+	clock := mock.NewMockAt(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	start := clock.Now()
+	deadline := start.Add(time.Minute)
+
+	clock.Add(10 * time.Second)
+	clock.Set(start.Add(-time.Hour))
+
+	after := clock.Now()
+	clock.Add(5 * time.Second)
+
+	for _, tt := range []struct {
+		name string
+		got  time.Duration
+		want time.Duration
+	}{
+		{"Since(start)", clock.Since(start), 15 * time.Second},
+		{"Since(after)", clock.Since(after), 5 * time.Second},
+		// The deadline was never reached, so the wall clock is used.
+		{"Until(deadline)", clock.Until(deadline), time.Hour + 55*time.Second},
+		{"Until(now)", clock.Until(clock.Now().Add(time.Second)), time.Second},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// Ensure that the times of a mock clock started at a real time compare correctly with real times.
+func TestMock_Monotonic_RealStart(t *testing.T) {
+	start := time.Now()
+	clock := mock.NewMockAt(start)
+
+	// The start time is kept as is, with its monotonic clock reading.
+	if now := clock.Now(); now != start {
+		t.Fatalf("Now() = %v, want %v", now, start)
+	}
+
+	clock.Add(time.Second)
+
+	if d := clock.Now().Sub(start); d != time.Second {
+		t.Fatalf("Now().Sub() = %v, want 1s", d)
+	}
+}
@@ -0,0 +1,67 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// defaultSettleTimeout is the default real time RunUntilIdle waits for the AfterFunc functions to settle.
+const defaultSettleTimeout = time.Second
+
+// defaultStackDepth is the default number of stack frames captured when a timer is created.
+// It covers the frames of the module wrapping the mock clock, and the first frames of the caller.
+const defaultStackDepth = 10
+
+// Option configures a mock clock on initialization.
+type Option func(m *Mock)
+
+// WithStartTime sets the current time of the mock clock on initialization.
+func WithStartTime(t time.Time) Option {
+	return func(m *Mock) { m.now = t }
+}
+
+// WithLocation sets the location of the times reported by the mock clock.
+// Times passed to Set are converted to this location.
+func WithLocation(loc *time.Location) Option {
+	return func(m *Mock) { m.loc = loc }
+}
+
+// WithMonotonic sets the monotonic clock reading of the mock clock on initialization.
+func WithMonotonic(d time.Duration) Option {
+	return func(m *Mock) { m.mono = d }
+}
+
+// WithBackwardJump sets the policy for the pending timers when Set moves the time of the mock clock backward.
+// The default policy is pkg.BackwardJumpKeep.
+func WithBackwardJump(policy pkg.BackwardJump) Option {
+	return func(m *Mock) { m.backward = policy }
+}
+
+// WithSyncAfterFunc makes the mock clock execute the functions passed to AfterFunc in the goroutine
+// moving the clock, so every function due is done by the time Add or Set returns, including
+// the functions of the timers they create within the same window.
+// A function must not wait for Add or Set to return, or it deadlocks.
+func WithSyncAfterFunc() Option {
+	return func(m *Mock) { m.syncFunc = true }
+}
+
+// WithTickerPolicy sets the policy of the tickers of the mock clock for ticks due before
+// the previous one is received. The default policy is pkg.TickerDrop.
+func WithTickerPolicy(policy pkg.TickerPolicy) Option {
+	return func(m *Mock) { m.tickerPolicy = policy }
+}
+
+// WithStackDepth sets the number of stack frames captured when a timer, a ticker or a context deadline
+// is created, which PendingTimers reports as the caller and the stack. The default depth of 10 frames
+// is enough for the caller and the first frames of its stack, and a depth of 0 captures nothing.
+func WithStackDepth(n int) Option {
+	return func(m *Mock) { m.stackDepth = n }
+}
+
+// WithSettleTimeout sets the real time RunUntilIdle and WaitForAllTimers wait for the functions passed
+// to AfterFunc to return or wait on the clock, before giving up. The default timeout is one second,
+// and a timeout less than or equal to zero waits forever.
+func WithSettleTimeout(d time.Duration) Option {
+	return func(m *Mock) { m.settleTimeout = d }
+}
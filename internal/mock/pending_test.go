@@ -0,0 +1,102 @@
+package mock_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/mock"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Ensure that pending timers are reported in firing order.
+func TestMock_PendingTimers(t *testing.T) {
+	clock := mock.NewMock()
+
+	if _, ok := clock.NextDeadline(); ok {
+		t.Fatal("unexpected deadline")
+	}
+
+	ticker := clock.Ticker(10 * time.Second)
+	ticker.(pkg.Labeler).SetLabel("heartbeat")
+
+	clock.AfterFunc(30*time.Second, func() {})
+	clock.Timer(5 * time.Second)
+
+	_, cancel := clock.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	want := []struct {
+		kind   pkg.TimerKind
+		next   time.Duration
+		period time.Duration
+		label  string
+	}{
+		{kind: pkg.KindTimer, next: 5 * time.Second},
+		{kind: pkg.KindTicker, next: 10 * time.Second, period: 10 * time.Second, label: "heartbeat"},
+		{kind: pkg.KindContextDeadline, next: 20 * time.Second},
+		{kind: pkg.KindAfterFunc, next: 30 * time.Second},
+	}
+
+	got := clock.PendingTimers()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d pending timers, got: %v", len(want), got)
+	}
+
+	for i, w := range want {
+		g := got[i]
+
+		if g.Kind != w.kind || !g.Next.Equal(time.Unix(0, 0).Add(w.next)) || g.Period != w.period || g.Label != w.label {
+			t.Errorf("unexpected pending timer #%d: %v", i, g)
+		}
+
+		if !strings.Contains(g.Caller, "pending_test.go:") {
+			t.Errorf("unexpected caller of pending timer #%d: %s", i, g.Caller)
+		}
+
+		if !strings.Contains(g.Stack, "TestMock_PendingTimers") {
+			t.Errorf("unexpected stack of pending timer #%d: %s", i, g.Stack)
+		}
+	}
+
+	if next, ok := clock.NextDeadline(); !ok || !next.Equal(time.Unix(5, 0)) {
+		t.Fatalf("unexpected next deadline: %v", next)
+	}
+
+	clock.Add(10 * time.Second)
+
+	if next, _ := clock.NextDeadline(); !next.Equal(time.Unix(20, 0)) {
+		t.Fatalf("unexpected next deadline: %v", next)
+	}
+
+	if n := len(clock.PendingTimers()); n != 3 {
+		t.Fatalf("expected 3 pending timers, got: %d", n)
+	}
+}
+
+// Ensure that the depth of the captured stacks is configurable, and that nothing is captured with a depth of 0.
+func TestMock_PendingTimers_StackDepth(t *testing.T) {
+	// The captured frames start with NewTimer and Mock.Timer, then the caller.
+	for _, tt := range []struct {
+		depth  int
+		frames int
+	}{
+		{depth: 0, frames: 0},
+		{depth: 3, frames: 1},
+		{depth: 32, frames: 3},
+	} {
+		clock := mock.NewMock(mock.WithStackDepth(tt.depth))
+		clock.Timer(time.Second)
+
+		info := clock.PendingTimers()[0]
+
+		if frames := strings.Count(info.Stack, "\n\t"); frames < tt.frames || tt.frames == 0 && frames != 0 {
+			t.Errorf("depth %d: %d frames captured, want %d at least: %s", tt.depth, frames, tt.frames, info.Stack)
+		}
+
+		if wantCaller := tt.depth > 0; strings.Contains(info.Caller, "pending_test.go:") != wantCaller {
+			t.Errorf("depth %d: unexpected caller %q", tt.depth, info.Caller)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package mock_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/mock"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Ensure that WaitForAllTimers fires the timers in order and returns while tickers are running.
+func TestMock_WaitForAllTimers(t *testing.T) {
+	clock := mock.NewMock()
+	ticker := clock.Ticker(time.Second)
+
+	var fired []time.Duration
+
+	record := func() { fired = append(fired, clock.Since(time.Unix(0, 0))) }
+
+	clock.AfterFunc(3*time.Second, func() {
+		record()
+		clock.AfterFunc(2*time.Second, record)
+	})
+	clock.AfterFunc(time.Second, record)
+
+	if now := clock.WaitForAllTimers(); !now.Equal(time.Unix(5, 0)) {
+		t.Fatalf("WaitForAllTimers() = %v, want %v", now, time.Unix(5, 0))
+	}
+
+	want := []time.Duration{time.Second, 3 * time.Second, 5 * time.Second}
+	if len(fired) != len(want) {
+		t.Fatalf("fired at %v, want %v", fired, want)
+	}
+
+	for i := range want {
+		if fired[i] != want[i] {
+			t.Fatalf("fired at %v, want %v", fired, want)
+		}
+	}
+
+	if n := ticker.(pkg.TickCounter).Delivered() + ticker.(pkg.TickCounter).Dropped(); n != 5 {
+		t.Fatalf("%d ticks, want 5", n)
+	}
+}
+
+// Ensure that RunUntilIdle stops when the next timer is due after the virtual time bound.
+func TestMock_RunUntilIdle_MaxVirtual(t *testing.T) {
+	clock := mock.NewMock()
+	clock.Timer(time.Second)
+	clock.Timer(time.Hour).(pkg.Labeler).SetLabel("late")
+
+	err := clock.RunUntilIdle(time.Minute, 0)
+
+	var idleErr *pkg.IdleError
+	if !errors.As(err, &idleErr) || !errors.Is(err, pkg.ErrNotIdle) {
+		t.Fatalf("RunUntilIdle() error = %v, want an *IdleError", err)
+	}
+
+	if len(idleErr.Pending) != 1 || idleErr.Pending[0].Label != "late" {
+		t.Fatalf("Pending = %v, want the late timer", idleErr.Pending)
+	}
+
+	if now := clock.Now(); !now.Equal(time.Unix(1, 0)) {
+		t.Fatalf("Now() = %v, want %v", now, time.Unix(1, 0))
+	}
+}
+
+// Ensure that RunUntilIdle stops after the maximum number of events.
+func TestMock_RunUntilIdle_MaxEvents(t *testing.T) {
+	clock := mock.NewMock()
+
+	var rearm func()
+
+	rearm = func() { clock.AfterFunc(time.Second, rearm) }
+	rearm()
+
+	err := clock.RunUntilIdle(0, 10)
+
+	var idleErr *pkg.IdleError
+	if !errors.As(err, &idleErr) {
+		t.Fatalf("RunUntilIdle() error = %v, want an *IdleError", err)
+	}
+
+	if len(idleErr.Pending) != 1 || idleErr.Pending[0].Kind != pkg.KindAfterFunc {
+		t.Fatalf("Pending = %v, want the rearmed function", idleErr.Pending)
+	}
+
+	if now := clock.Now(); !now.Equal(time.Unix(10, 0)) {
+		t.Fatalf("Now() = %v, want %v", now, time.Unix(10, 0))
+	}
+}
+
+// Ensure that WaitForAllTimers does not wait for a function waiting on the clock to return.
+func TestMock_WaitForAllTimers_ParkedFunc(t *testing.T) {
+	clock := mock.NewMock()
+	done := make(chan struct{})
+
+	clock.AfterFunc(
+		time.Second, func() {
+			clock.Sleep(time.Second)
+			close(done)
+		},
+	)
+
+	if now := clock.WaitForAllTimers(); !now.Equal(time.Unix(2, 0)) {
+		t.Fatalf("WaitForAllTimers() = %v, want %v", now, time.Unix(2, 0))
+	}
+
+	expectDone(t, done)
+}
+
+// Ensure that RunUntilIdle and WaitForAllTimers give up on a function blocked on something else than the clock.
+func TestMock_RunUntilIdle_Unsettled(t *testing.T) {
+	clock := mock.NewMock(mock.WithSettleTimeout(10 * time.Millisecond))
+	release := make(chan struct{})
+
+	defer close(release)
+
+	clock.AfterFunc(time.Second, func() { <-release })
+	clock.Timer(time.Hour)
+
+	if now := clock.WaitForAllTimers(); !now.Equal(time.Unix(1, 0)) {
+		t.Fatalf("WaitForAllTimers() = %v, want %v", now, time.Unix(1, 0))
+	}
+
+	var idleErr *pkg.IdleError
+	if err := clock.RunUntilIdle(0, 0); !errors.As(err, &idleErr) || len(idleErr.Pending) != 1 {
+		t.Fatalf("RunUntilIdle() error = %v, want an *IdleError with the pending timer", err)
+	}
+}
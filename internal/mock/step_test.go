@@ -0,0 +1,123 @@
+package mock_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/mock"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Ensure that Step fires exactly the next timer.
+func TestMock_Step(t *testing.T) {
+	clock := mock.NewMock()
+	timer1 := clock.Timer(3 * time.Second)
+	timer2 := clock.Timer(5 * time.Second)
+
+	event, ok := clock.Step()
+	if !ok || event.Kind != pkg.KindTimer || !event.Time.Equal(time.Unix(3, 0)) {
+		t.Fatalf("Step() = %v, %v; want a timer at %v", event, ok, time.Unix(3, 0))
+	}
+
+	if now := clock.Now(); !now.Equal(time.Unix(3, 0)) {
+		t.Fatalf("Now() = %v, want %v", now, time.Unix(3, 0))
+	}
+
+	expectTick(t, timer1.Chan())
+	expectNoTick(t, timer2.Chan())
+
+	if _, ok := clock.Step(); !ok {
+		t.Fatal("Step() = false, want true")
+	}
+
+	expectTick(t, timer2.Chan())
+
+	if event, ok := clock.Step(); ok {
+		t.Fatalf("Step() = %v, true; want false", event)
+	}
+}
+
+// Ensure that StepN fires at most n timers.
+func TestMock_StepN(t *testing.T) {
+	clock := mock.NewMock()
+	clock.Ticker(2 * time.Second)
+	clock.Timer(3 * time.Second)
+
+	events := clock.StepN(3)
+
+	want := []pkg.TimerKind{pkg.KindTicker, pkg.KindTimer, pkg.KindTicker}
+	if len(events) != len(want) {
+		t.Fatalf("StepN() = %v, want %v", events, want)
+	}
+
+	for i, e := range events {
+		if e.Kind != want[i] {
+			t.Errorf("event %d = %v, want a %v", i, e, want[i])
+		}
+	}
+
+	if now := clock.Now(); !now.Equal(time.Unix(4, 0)) {
+		t.Fatalf("Now() = %v, want %v", now, time.Unix(4, 0))
+	}
+
+	clock = mock.NewMock()
+	clock.Timer(time.Second)
+
+	if events := clock.StepN(3); len(events) != 1 {
+		t.Fatalf("StepN() = %v, want 1 event", events)
+	}
+}
+
+// Ensure that RunUntil steps until the predicate is satisfied.
+func TestMock_RunUntil(t *testing.T) {
+	clock := mock.NewMock()
+	clock.Ticker(time.Second)
+	clock.Timer(5 * time.Second).(pkg.Labeler).SetLabel("deadline")
+
+	events, err := clock.RunUntil(func(e pkg.Event) bool { return e.Label == "deadline" })
+	if err != nil {
+		t.Fatalf("RunUntil() error = %v", err)
+	}
+
+	if n := len(events); n != 6 {
+		t.Fatalf("RunUntil() = %v, want 6 events", events)
+	}
+
+	if now := clock.Now(); !now.Equal(time.Unix(5, 0)) {
+		t.Fatalf("Now() = %v, want %v", now, time.Unix(5, 0))
+	}
+}
+
+// Ensure that RunUntil fails when no timer is left.
+func TestMock_RunUntil_NoPendingTimers(t *testing.T) {
+	clock := mock.NewMock()
+	clock.Timer(time.Second)
+
+	events, err := clock.RunUntil(func(pkg.Event) bool { return false })
+	if !errors.Is(err, pkg.ErrNoPendingTimers) {
+		t.Fatalf("RunUntil() error = %v, want %v", err, pkg.ErrNoPendingTimers)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("RunUntil() = %v, want 1 event", events)
+	}
+}
+
+// Ensure that Step fires an overdue timer without moving the clock backward.
+func TestMock_Step_Overdue(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := mock.NewMockAt(start, mock.WithSyncAfterFunc())
+
+	fired := false
+	clock.AfterFunc(-time.Hour, func() { fired = true })
+
+	event, ok := clock.Step()
+	if !ok || !fired || !event.Time.Equal(start) {
+		t.Fatalf("Step() = %v, %v; want the function fired at %v", event, ok, start)
+	}
+
+	if now := clock.Now(); !now.Equal(start) {
+		t.Fatalf("Now() = %v, want %v", now, start)
+	}
+}
@@ -1,64 +1,264 @@
 package mock
 
 import (
+	"sync"
 	"time"
 
-	"github.com/itbasis/go-clock/v2/internal"
+	"github.com/itbasis/go-clock/v2/internal/timechan"
+	"github.com/itbasis/go-clock/v2/pkg"
 )
 
 // Ticker holds a channel that receives "ticks" at regular intervals.
 type Ticker struct {
-	c       chan time.Time
-	next    time.Time     // next tick time
-	mock    *Mock         // mock clock, if set
-	d       time.Duration // time between ticks
-	stopped bool          // True if stopped, false if running
+	heapSlot
+
+	c         chan time.Time
+	next      time.Time        // next tick time
+	mock      *Mock            // mock clock, if set
+	d         time.Duration    // time between ticks
+	stopped   bool             // True if stopped, false if running
+	label     string           // label reported in snapshots
+	pcs       []uintptr        // stack of the goroutine which created the ticker
+	policy    pkg.TickerPolicy // policy for ticks due before the previous one is received
+	done      chan struct{}    // closed by Stop and Reset to abort the pending deliveries
+	queue     []time.Time      // ticks waiting for delivery with TickerQueue
+	pumping   bool             // True if a goroutine is delivering the queued ticks
+	sending   int              // number of ticks taken for sending with TickerBlock and TickerQueue, not sent yet
+	delivered int              // number of ticks sent on c
+	dropped   int              // number of ticks dropped or discarded
+
+	// sendMu is held while a tick is sent outside of the clock lock, with TickerBlock and TickerQueue.
+	// Stop and Reset wait for it, so the sending is either done or aborted by the time they return.
+	// It MUST be acquired before the clock lock.
+	sendMu sync.Mutex
 }
 
 func NewTicker(c chan time.Time, m *Mock, duration time.Duration) *Ticker {
 	return &Ticker{
-		c:    c,
-		mock: m,
-		d:    duration,
-		next: m.now.Add(duration),
+		heapSlot: newHeapSlot(),
+		c:        c,
+		mock:     m,
+		d:        duration,
+		next:     m.now.Add(duration),
+		pcs:      callers(m.stackDepth),
+		policy:   m.tickerPolicy,
+		done:     make(chan struct{}),
 	}
 }
 
 func (t *Ticker) Chan() <-chan time.Time { return t.c }
 
 // Stop turns off the ticker.
+// No stale tick is received from the channel after Stop returns.
 func (t *Ticker) Stop() {
 	t.mock.mu.Lock()
 	t.mock.removeClockTimer(t)
 	t.stopped = true
+	t.abort()
 	t.mock.mu.Unlock()
+
+	t.waitSending()
 }
 
-// Reset resets the ticker to a new duration.
+// Reset stops the ticker and resets its period to the specified duration.
+// No stale tick is received from the channel after Reset returns.
+// The duration must be greater than zero; if not, Reset will panic.
 func (t *Ticker) Reset(duration time.Duration) {
-	t.mock.mu.Lock()
-	defer t.mock.mu.Unlock()
-
-	if t.stopped {
-		t.mock.timers = append(t.mock.timers, t)
-		t.stopped = false
+	if duration <= 0 {
+		panic("non-positive interval for Ticker.Reset")
 	}
 
+	t.mock.mu.Lock()
+	t.abort()
+
+	t.stopped = false
 	t.d = duration
 	t.next = t.mock.now.Add(duration)
+	t.mock.resetClockTimer(t)
+	t.mock.mu.Unlock()
+
+	t.waitSending()
+}
+
+// abort discards the ticks not delivered yet, and aborts the sending in progress, if any.
+// The sending goroutine counts the tick it was sending. t.mock.mu MUST be held
+// when this method is called.
+func (t *Ticker) abort() {
+	// Only the channel of TickerDrop is buffered: the other channels are drained by receiving
+	// from a sending goroutine, which would count the tick as delivered.
+	if cap(t.c) > 0 && timechan.Drain(t.c) {
+		t.delivered--
+		t.dropped++
+	}
+
+	close(t.done)
+	t.done = make(chan struct{})
+
+	t.dropped += len(t.queue)
+	t.queue = nil
 }
 
 func (t *Ticker) Next() time.Time { return t.next }
 
-func (t *Ticker) Tick(now time.Time) {
+func (t *Ticker) setNext(next time.Time) { t.next = next }
+
+// SetLabel sets the label of the ticker reported in snapshots.
+func (t *Ticker) SetLabel(label string) {
+	t.mock.mu.Lock()
+	t.label = label
+	t.mock.mu.Unlock()
+}
+
+// Delivered returns the number of ticks sent on the channel, except the ones discarded by Stop or Reset.
+func (t *Ticker) Delivered() int {
+	t.mock.mu.Lock()
+	defer t.mock.mu.Unlock()
+
+	return t.delivered
+}
+
+// Dropped returns the number of ticks dropped, or discarded by Stop or Reset before being delivered.
+func (t *Ticker) Dropped() int {
+	t.mock.mu.Lock()
+	defer t.mock.mu.Unlock()
+
+	return t.dropped
+}
+
+func (t *Ticker) info() pkg.TimerInfo {
+	caller, stack := formatCallers(t.pcs)
+
+	return pkg.TimerInfo{Kind: pkg.KindTicker, Next: t.next, Period: t.d, Label: t.label, Caller: caller, Stack: stack}
+}
+
+func (t *Ticker) fire(now time.Time) (pkg.Event, func(event *pkg.Event)) {
+	event := pkg.Event{Kind: pkg.KindTicker, Label: t.label, Time: now}
+
+	t.next = now.Add(t.d)
+	t.mock.fixClockTimer(t)
+
+	switch t.policy {
+	case pkg.TickerBlock:
+		done := t.done
+
+		t.sending++
+		t.mock.watchTicker(t)
+
+		// block on the delivery once the lock is released, until Stop or Reset aborts it
+		return event, func(event *pkg.Event) {
+			t.sendMu.Lock()
+			defer t.sendMu.Unlock()
+
+			// Stop or Reset may have returned since the tick was fired.
+			t.mock.mu.Lock()
+			if t.done != done {
+				t.sending--
+				t.count(true)
+				t.mock.mu.Unlock()
+
+				event.Dropped = true
+
+				return
+			}
+			t.mock.mu.Unlock()
+
+			event.Dropped = t.send(now, done)
+		}
+	case pkg.TickerQueue:
+		t.queue = append(t.queue, now)
+		t.mock.watchTicker(t)
+
+		if !t.pumping {
+			t.pumping = true
+
+			go t.pump()
+		}
+
+		return event, nil
+	default:
+		select {
+		case t.c <- now:
+		default:
+			event.Dropped = true
+		}
+
+		t.count(event.Dropped)
+		t.mock.watchTicker(t)
+
+		return event, nil
+	}
+}
+
+// waitSending waits until the sending in progress, if any, is done or aborted.
+// t.mock.mu MUST NOT be held when this method is called.
+func (t *Ticker) waitSending() {
+	t.sendMu.Lock()
+	t.sendMu.Unlock() //nolint:staticcheck // empty critical section to wait for the sending
+}
+
+// send sends a tick on the channel until it is received or Stop or Reset closes done.
+// It reports whether the tick was dropped, after counting it. t.sendMu MUST be held
+// when this method is called.
+func (t *Ticker) send(now time.Time, done chan struct{}) bool {
+	dropped := true
+
 	select {
 	case t.c <- now:
-	default:
+		dropped = false
+	case <-done:
 	}
 
 	t.mock.mu.Lock()
-	t.next = now.Add(t.d)
+	t.sending--
+	t.count(dropped)
+	t.mock.notifyChanged()
 	t.mock.mu.Unlock()
 
-	internal.Gosched()
+	return dropped
+}
+
+// unreceived reports whether the previous tick of the registered ticker has not been received yet,
+// so no goroutine is parked on its channel. t.mock.mu MUST be held when this method is called.
+func (t *Ticker) unreceived() bool {
+	return t.scheduled() && (len(t.c) > 0 || len(t.queue) > 0 || t.sending > 0)
+}
+
+// count counts a tick sent on the channel or dropped. t.mock.mu MUST be held
+// when this method is called.
+func (t *Ticker) count(dropped bool) {
+	if dropped {
+		t.dropped++
+	} else {
+		t.delivered++
+	}
+}
+
+// pump delivers the queued ticks in order until the queue is empty.
+func (t *Ticker) pump() {
+	for t.pumpNext() {
+	}
+}
+
+// pumpNext delivers the next queued tick. It reports false once the queue is empty.
+func (t *Ticker) pumpNext() bool {
+	t.sendMu.Lock()
+	defer t.sendMu.Unlock()
+
+	t.mock.mu.Lock()
+
+	if len(t.queue) == 0 {
+		t.pumping = false
+		t.mock.mu.Unlock()
+
+		return false
+	}
+
+	now, done := t.queue[0], t.done
+	t.queue = t.queue[1:]
+	t.sending++
+	t.mock.mu.Unlock()
+
+	t.send(now, done)
+
+	return true
 }
@@ -0,0 +1,185 @@
+package mock_test
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/mock"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// expectCounts fails the test if the ticker counts are not the expected ones.
+func expectCounts(t *testing.T, ticker pkg.Ticker, delivered, dropped int) {
+	t.Helper()
+
+	c := ticker.(pkg.TickCounter)
+	if d, p := c.Delivered(), c.Dropped(); d != delivered || p != dropped {
+		t.Fatalf("Delivered(), Dropped() = %d, %d; want %d, %d", d, p, delivered, dropped)
+	}
+}
+
+// Ensure that ticks are dropped when the channel is full by default.
+func TestMock_Ticker_Drop(t *testing.T) {
+	clock := mock.NewMock()
+	ticker := clock.Ticker(time.Second)
+
+	clock.Add(3 * time.Second)
+	expectCounts(t, ticker, 1, 2)
+	expectTickAt(t, ticker.Chan(), time.Unix(1, 0))
+
+	clock.Add(time.Second)
+	expectCounts(t, ticker, 2, 2)
+
+	// The tick discarded by Stop is counted as dropped.
+	ticker.Stop()
+	expectCounts(t, ticker, 1, 3)
+}
+
+// Ensure that the clock waits for every tick to be received with TickerBlock.
+func TestMock_Ticker_Block(t *testing.T) {
+	clock := mock.NewMock(mock.WithTickerPolicy(pkg.TickerBlock))
+	ticker := clock.Ticker(time.Second)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		clock.Add(3 * time.Second)
+	}()
+
+	for i := int64(1); i <= 3; i++ {
+		if tick := <-ticker.Chan(); !tick.Equal(time.Unix(i, 0)) {
+			t.Fatalf("tick at %v, want %v", tick, time.Unix(i, 0))
+		}
+	}
+
+	expectDone(t, done)
+	expectCounts(t, ticker, 3, 0)
+}
+
+// Ensure that Stop releases the clock blocked on a tick with TickerBlock.
+func TestMock_Ticker_BlockStop(t *testing.T) {
+	clock := mock.NewMock(mock.WithTickerPolicy(pkg.TickerBlock))
+	ticker := clock.Ticker(time.Second)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		clock.Add(3 * time.Second)
+	}()
+
+	<-ticker.Chan()
+	expectNotDone(t, done)
+
+	ticker.Stop()
+	expectDone(t, done)
+	expectCounts(t, ticker, 1, 1)
+}
+
+// Ensure that every tick is delivered in order with TickerQueue.
+func TestMock_Ticker_Queue(t *testing.T) {
+	clock := mock.NewMock(mock.WithTickerPolicy(pkg.TickerQueue))
+	ticker := clock.Ticker(time.Second)
+
+	report := clock.AddAndReport(3 * time.Second)
+	if n := len(report.Events); n != 3 || report.Events[2].Dropped {
+		t.Fatalf("events = %v, want 3 ticks delivered", report.Events)
+	}
+
+	for i := int64(1); i <= 3; i++ {
+		if tick := <-ticker.Chan(); !tick.Equal(time.Unix(i, 0)) {
+			t.Fatalf("tick at %v, want %v", tick, time.Unix(i, 0))
+		}
+	}
+
+	expectCounts(t, ticker, 3, 0)
+
+	// The queued ticks are discarded by Stop.
+	clock.Add(2 * time.Second)
+	ticker.Stop()
+	expectNoTick(t, ticker.Chan())
+
+	c := ticker.(pkg.TickCounter)
+	if d, p := c.Delivered(), c.Dropped(); d+p != 5 {
+		t.Fatalf("Delivered(), Dropped() = %d, %d; want 5 ticks in total", d, p)
+	}
+}
+
+// Ensure that a tick being delivered with TickerQueue is counted once when the ticker is stopped.
+func TestMock_Ticker_QueueStop(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		clock := mock.NewMock(mock.WithTickerPolicy(pkg.TickerQueue))
+		ticker := clock.Ticker(time.Second)
+
+		clock.Add(3 * time.Second)
+		runtime.Gosched() // let the first queued tick be sent
+		ticker.Stop()
+
+		// The counts hold whenever the aborted sending completes.
+		for j := 0; j < 3; j++ {
+			c := ticker.(pkg.TickCounter)
+			if d, p := c.Delivered(), c.Dropped(); d+p != 3 {
+				t.Fatalf("Delivered(), Dropped() = %d, %d; want 3 ticks in total", d, p)
+			}
+
+			runtime.Gosched()
+		}
+
+		expectNoTick(t, ticker.Chan())
+	}
+}
+
+// Ensure that no tick is received after Stop returns and that every fired tick is counted once,
+// while a separate goroutine receives the ticks.
+func TestMock_Ticker_StopWhileReceiving(t *testing.T) {
+	for _, policy := range []pkg.TickerPolicy{pkg.TickerDrop, pkg.TickerBlock, pkg.TickerQueue} {
+		t.Run(policy.String(), func(t *testing.T) {
+			for i := 0; i < 200; i++ {
+				clock := mock.NewMock(mock.WithTickerPolicy(policy))
+				ticker := clock.Ticker(time.Second)
+				counter := ticker.(pkg.TickCounter)
+
+				var received atomic.Int64
+
+				stop, receiving := make(chan struct{}), make(chan struct{})
+
+				go func() {
+					defer close(receiving)
+
+					for {
+						select {
+						case <-ticker.Chan():
+							received.Add(1)
+						case <-stop:
+							return
+						}
+					}
+				}()
+
+				reports := make(chan pkg.AdvanceReport)
+
+				go func() { reports <- clock.AddAndReport(5 * time.Second) }()
+
+				runtime.Gosched()
+				ticker.Stop()
+				delivered := counter.Delivered()
+
+				fired := len((<-reports).Events)
+
+				close(stop)
+				<-receiving
+
+				if n := received.Load(); n != int64(delivered) {
+					t.Fatalf("%d ticks received, want the %d delivered before Stop returned", n, delivered)
+				}
+
+				if d, p := counter.Delivered(), counter.Dropped(); d != delivered || d+p != fired {
+					t.Fatalf("Delivered(), Dropped() = %d, %d; want %d delivered and %d ticks in total", d, p, delivered, fired)
+				}
+			}
+		})
+	}
+}
@@ -3,75 +3,119 @@ package mock
 import (
 	"time"
 
-	"github.com/itbasis/go-clock/v2/internal"
+	"github.com/itbasis/go-clock/v2/internal/timechan"
+	"github.com/itbasis/go-clock/v2/pkg"
 )
 
 // Timer represents a single event.
 // The current time will be sent on C, unless the timer was created by AfterFunc.
 type Timer struct {
+	heapSlot
+
 	c       chan time.Time
-	next    time.Time // next tick time
-	mock    *Mock     // mock clock, if set
-	fn      func()    // AfterFunc function, if set
-	stopped bool      // True if stopped, false if running
+	next    time.Time     // next tick time
+	mock    *Mock         // mock clock, if set
+	fn      func()        // AfterFunc function, if set
+	inline  bool          // True if fn runs in the goroutine advancing the clock
+	stopped bool          // True if stopped, false if running
+	kind    pkg.TimerKind // how the timer was created
+	period  time.Duration // period reported in snapshots, for the timers relaying a ticker
+	label   string        // label reported in snapshots
+	pcs     []uintptr     // stack of the goroutine which created the timer
 }
 
 func NewTimer(c chan time.Time, f func(), m *Mock, d time.Duration) *Timer {
+	kind := pkg.KindTimer
+	if f != nil {
+		kind = pkg.KindAfterFunc
+	}
+
 	return &Timer{
-		c:    c,
-		fn:   f,
-		mock: m,
-		next: m.now.Add(d),
+		heapSlot: newHeapSlot(),
+		c:        c,
+		fn:       f,
+		mock:     m,
+		next:     m.now.Add(d),
+		kind:     kind,
+		pcs:      callers(m.stackDepth),
 	}
 }
 
 func (t *Timer) Chan() <-chan time.Time { return t.c }
 
-// Stop turns off the ticker.
+// Stop prevents the timer from firing.
+// It returns true if the call stops the timer, including when its value has not been received yet,
+// and false if the timer has already expired or been stopped.
+// No stale value is received from the channel after Stop returns.
 func (t *Timer) Stop() bool {
 	t.mock.mu.Lock()
-	registered := !t.stopped
-	t.mock.removeClockTimer(t)
-	t.stopped = true
-	t.mock.mu.Unlock()
+	defer t.mock.mu.Unlock()
 
-	return registered
+	return t.stop()
 }
 
-// Reset changes the expiry time of the timer
+// Reset changes the expiry time of the timer.
+// It returns the same value as Stop would, and no stale value is received from the channel after Reset returns.
 func (t *Timer) Reset(duration time.Duration) bool {
 	t.mock.mu.Lock()
-	t.next = t.mock.now.Add(duration)
 	defer t.mock.mu.Unlock()
 
-	registered := !t.stopped
-
-	if t.stopped {
-		t.mock.timers = append(t.mock.timers, t)
-	}
+	registered := t.stop()
 
+	t.next = t.mock.now.Add(duration)
 	t.stopped = false
+	t.mock.resetClockTimer(t)
 
 	return registered
 }
 
+// stop unregisters the timer and drains its channel. t.mock.mu MUST be held
+// when this method is called.
+func (t *Timer) stop() bool {
+	registered := !t.stopped
+
+	t.mock.removeClockTimer(t)
+	t.stopped = true
+
+	return timechan.Drain(t.c) || registered
+}
+
 func (t *Timer) Next() time.Time { return t.next }
 
-func (t *Timer) Tick(now time.Time) {
-	// a gosched() after ticking, to allow any consequences of the
-	// tick to complete
-	defer internal.Gosched()
+func (t *Timer) setNext(next time.Time) { t.next = next }
 
+// SetLabel sets the label of the timer reported in snapshots.
+func (t *Timer) SetLabel(label string) {
 	t.mock.mu.Lock()
+	t.label = label
+	t.mock.mu.Unlock()
+}
 
-	if t.fn != nil {
-		// defer function execution until the lock is released, and
-		defer func() { go t.fn() }()
-	} else {
-		t.c <- now
-	}
+func (t *Timer) info() pkg.TimerInfo {
+	caller, stack := formatCallers(t.pcs)
+
+	return pkg.TimerInfo{Kind: t.kind, Next: t.next, Period: t.period, Label: t.label, Caller: caller, Stack: stack}
+}
+
+func (t *Timer) fire(now time.Time) (pkg.Event, func(event *pkg.Event)) {
+	event := pkg.Event{Kind: t.kind, Label: t.label, Time: now}
 
 	t.mock.removeClockTimer(t)
 	t.stopped = true
-	t.mock.mu.Unlock()
+
+	switch {
+	case t.fn != nil && t.inline:
+		// execute the function once the lock is released
+		return event, func(*pkg.Event) { t.fn() }
+	case t.fn != nil:
+		t.mock.startCallback(t.fn)
+	default:
+		select {
+		case t.c <- now:
+		default:
+			event.Dropped = true
+		}
+	}
+
+	return event, nil
 }
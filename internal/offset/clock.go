@@ -0,0 +1,68 @@
+// Package offset implements a clock shifted from a base clock by an adjustable offset.
+package offset
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/relay"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Clock reports the time of a base clock shifted by an offset.
+// The durations of timers, tickers and sleeps are the ones of the base clock.
+type Clock struct {
+	base   pkg.Clock
+	offset atomic.Int64 // offset as a time.Duration
+	relay  relay.Clock
+}
+
+func NewClock(base pkg.Clock, offset time.Duration) *Clock {
+	c := &Clock{base: base}
+	c.offset.Store(int64(offset))
+	c.relay = relay.Clock{
+		Base:     base,
+		Now:      c.Now,
+		Duration: func(d time.Duration) time.Duration { return d },
+	}
+
+	return c
+}
+
+// Offset returns the offset from the base clock.
+func (c *Clock) Offset() time.Duration { return time.Duration(c.offset.Load()) }
+
+// SetOffset changes the offset from the base clock. It is safe for concurrent use.
+// Pending timers, tickers and contexts keep their durations.
+func (c *Clock) SetOffset(offset time.Duration) { c.offset.Store(int64(offset)) }
+
+func (c *Clock) After(d time.Duration) <-chan time.Time { return c.Timer(d).Chan() }
+
+func (c *Clock) AfterFunc(d time.Duration, f func()) pkg.Timer { return c.base.AfterFunc(d, f) }
+
+// Now returns the time of the base clock shifted by the offset.
+// Changing the offset moves the time like a step of the clock, so elapsed times measured across the change include it.
+func (c *Clock) Now() time.Time { return c.base.Now().Add(c.Offset()) }
+
+func (c *Clock) Since(t time.Time) time.Duration { return c.Now().Sub(t) }
+
+func (c *Clock) Until(t time.Time) time.Duration { return t.Sub(c.Now()) }
+
+func (c *Clock) Sleep(d time.Duration) { c.base.Sleep(d) }
+
+func (c *Clock) Tick(d time.Duration) <-chan time.Time { return c.Ticker(d).Chan() }
+
+func (c *Clock) Ticker(d time.Duration) pkg.Ticker { return c.relay.Ticker(d) }
+
+func (c *Clock) Timer(d time.Duration) pkg.Timer { return c.relay.Timer(d) }
+
+func (c *Clock) WithTimeout(parent context.Context, t time.Duration) (context.Context, context.CancelFunc) {
+	return c.WithDeadline(parent, c.Now().Add(t))
+}
+
+// WithDeadline returns a context done when the base clock reaches the deadline shifted back by the current offset.
+// The Deadline method of the context reports the deadline on this clock.
+func (c *Clock) WithDeadline(parent context.Context, d time.Time) (context.Context, context.CancelFunc) {
+	return c.relay.WithDeadline(parent, d)
+}
@@ -0,0 +1,140 @@
+package offset_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/clocktest"
+	"github.com/itbasis/go-clock/v2/internal/mock"
+	"github.com/itbasis/go-clock/v2/internal/offset"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+const year = 365 * 24 * time.Hour
+
+// Ensure that the time is shifted by the offset, which can be changed at runtime.
+func TestClock_Now(t *testing.T) {
+	base := mock.NewMock()
+	clock := offset.NewClock(base, year)
+
+	if now := clock.Now(); !now.Equal(base.Now().Add(year)) {
+		t.Fatalf("Now() = %v, want %v", now, base.Now().Add(year))
+	}
+
+	start := clock.Now()
+	base.Add(time.Minute)
+
+	if d := clock.Since(start); d != time.Minute {
+		t.Fatalf("Since() = %v, want 1m", d)
+	}
+
+	clock.SetOffset(2 * year)
+
+	if off := clock.Offset(); off != 2*year {
+		t.Fatalf("Offset() = %v, want %v", off, 2*year)
+	}
+
+	if now := clock.Now(); !now.Equal(base.Now().Add(2 * year)) {
+		t.Fatalf("Now() = %v, want %v", now, base.Now().Add(2*year))
+	}
+
+	// Elapsed times measured across the change include it.
+	if d := clock.Since(start); d != year+time.Minute {
+		t.Fatalf("Since() = %v, want %v", d, year+time.Minute)
+	}
+}
+
+// Ensure that the timers keep their durations and send the shifted time by the time the base clock has moved.
+func TestClock_Timer(t *testing.T) {
+	base := mock.NewMock()
+	clock := offset.NewClock(base, year)
+	timer := clock.Timer(time.Second)
+
+	if infos := base.PendingTimers(); len(infos) != 1 || infos[0].Kind != pkg.KindTimer {
+		t.Fatalf("PendingTimers() = %v, want a timer", infos)
+	}
+
+	base.Add(time.Second)
+
+	select {
+	case now := <-timer.Chan():
+		if !now.Equal(base.Now().Add(year)) {
+			t.Fatalf("timer sent %v, want %v", now, base.Now().Add(year))
+		}
+	default:
+		t.Fatal("timer did not fire")
+	}
+}
+
+// Ensure that the tickers send the shifted time on each tick by the time the base clock has moved.
+func TestClock_Ticker(t *testing.T) {
+	base := mock.NewMock()
+	clock := offset.NewClock(base, year)
+	ticker := clock.Ticker(time.Second)
+
+	infos := base.PendingTimers()
+	if len(infos) != 1 || infos[0].Kind != pkg.KindTicker || infos[0].Period != time.Second {
+		t.Fatalf("PendingTimers() = %v, want a ticker of 1s", infos)
+	}
+
+	for i := 0; i < 3; i++ {
+		base.Add(time.Second)
+
+		select {
+		case now := <-ticker.Chan():
+			if !now.Equal(base.Now().Add(year)) {
+				t.Fatalf("tick %d sent %v, want %v", i, now, base.Now().Add(year))
+			}
+		default:
+			t.Fatalf("tick %d not sent", i)
+		}
+	}
+
+	// Tickers are not waited for.
+	base.WaitForAllTimers()
+	ticker.Stop()
+
+	if infos := base.PendingTimers(); len(infos) != 0 {
+		t.Fatalf("PendingTimers() = %v after Stop, want none", infos)
+	}
+}
+
+// Ensure that contexts expire with the base clock and report the shifted deadline.
+func TestClock_WithDeadline(t *testing.T) {
+	base := mock.NewMock()
+	clock := offset.NewClock(base, year)
+	deadline := clock.Now().Add(time.Minute)
+
+	ctx, cancel := clock.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	if d, ok := ctx.Deadline(); !ok || !d.Equal(deadline) {
+		t.Fatalf("Deadline() = %v, %v; want %v", d, ok, deadline)
+	}
+
+	clock.SetOffset(0)
+	base.Add(time.Minute - time.Nanosecond)
+
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("Err() = %v before the deadline", err)
+	}
+
+	base.Add(time.Nanosecond)
+
+	if err := ctx.Err(); err == nil {
+		t.Fatal("Err() = nil after the deadline")
+	}
+}
+
+func TestClock_Conformance(t *testing.T) {
+	clocktest.RunConformance(
+		t, func(*testing.T) clocktest.Subject {
+			base := mock.NewMock()
+			s := clocktest.Mock(base)
+			s.Clock = offset.NewClock(base, -year)
+
+			return s
+		},
+	)
+}
@@ -0,0 +1,122 @@
+// Package relay implements the timers and tickers of clocks wrapping a base clock,
+// which report times of their own on the channels.
+package relay
+
+import (
+	"context"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Clock describes a clock wrapping a base clock.
+type Clock struct {
+	Base pkg.Clock
+
+	// Now returns the current time of the wrapping clock, sent on the channels.
+	Now func() time.Time
+	// Duration converts a duration of the wrapping clock to a duration of the base clock.
+	Duration func(d time.Duration) time.Duration
+}
+
+// InlineClock is implemented by the base clocks executing functions in the goroutine moving them, like a mock clock.
+// The timers and tickers are then relayed by this goroutine, so their values reach the channels by the time
+// the base clock has moved, and the base clock reports them with their own kind.
+type InlineClock interface {
+	// InlineFunc executes f in the goroutine moving the clock after the duration d. The timer is reported
+	// with the kind and the period.
+	InlineFunc(kind pkg.TimerKind, period, d time.Duration, f func()) pkg.Timer
+}
+
+// Timer creates a timer sending the current time of the wrapping clock when it fires.
+func (c Clock) Timer(d time.Duration) pkg.Timer {
+	t := &Timer{clock: c, c: make(chan time.Time, 1)}
+
+	t.mu.Lock()
+	t.start(d)
+	t.mu.Unlock()
+
+	return t
+}
+
+// AfterFunc creates a timer executing f in its own goroutine when it fires.
+func (c Clock) AfterFunc(d time.Duration, f func()) pkg.Timer {
+	return &funcTimer{clock: c, timer: c.Base.AfterFunc(c.Duration(d), f)}
+}
+
+// Ticker creates a ticker sending the current time of the wrapping clock on each tick.
+// The duration must be greater than zero; if not, Ticker will panic.
+func (c Clock) Ticker(d time.Duration) pkg.Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+
+	t := &Ticker{clock: c, c: make(chan time.Time, 1)}
+
+	t.mu.Lock()
+	t.start(d)
+	t.mu.Unlock()
+
+	return t
+}
+
+// WithDeadline returns a context done when the base clock reaches the time corresponding to the deadline.
+// The Deadline method of the context reports the deadline on the wrapping clock.
+func (c Clock) WithDeadline(parent context.Context, d time.Time) (context.Context, context.CancelFunc) {
+	if cur, ok := parent.Deadline(); ok && cur.Before(d) {
+		// The current deadline is already sooner than the new one.
+		return context.WithCancel(parent)
+	}
+
+	// The deadline of the parent is on the wrapping clock, so the base clock must not compare it with its own deadlines.
+	ctx, cancel := c.Base.WithTimeout(noDeadlineCtx{Context: parent}, c.Duration(d.Sub(c.Now())))
+
+	return &deadlineCtx{Context: ctx, deadline: d}, cancel
+}
+
+// afterFunc executes f after the duration d of the base clock, in the goroutine moving the base clock
+// if it is an InlineClock, which then reports the timer with the kind and the period.
+func (c Clock) afterFunc(kind pkg.TimerKind, period, d time.Duration, f func()) pkg.Timer {
+	if inline, ok := c.Base.(InlineClock); ok {
+		return inline.InlineFunc(kind, period, d, f)
+	}
+
+	return c.Base.AfterFunc(d, f)
+}
+
+// baseInterval converts the interval of a ticker, which must stay positive on the base clock.
+func (c Clock) baseInterval(d time.Duration) time.Duration {
+	if bd := c.Duration(d); bd > 0 {
+		return bd
+	}
+
+	return 1
+}
+
+// funcTimer is a timer created by AfterFunc.
+type funcTimer struct {
+	clock Clock
+	timer pkg.Timer
+}
+
+func (t *funcTimer) Chan() <-chan time.Time { return nil }
+
+func (t *funcTimer) Stop() bool { return t.timer.Stop() }
+
+func (t *funcTimer) Reset(d time.Duration) bool { return t.timer.Reset(t.clock.Duration(d)) }
+
+// noDeadlineCtx hides the deadline of a context.
+type noDeadlineCtx struct {
+	context.Context
+}
+
+func (noDeadlineCtx) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+// deadlineCtx reports the deadline of a context of the base clock on the wrapping clock.
+type deadlineCtx struct {
+	context.Context
+
+	deadline time.Time
+}
+
+func (c *deadlineCtx) Deadline() (time.Time, bool) { return c.deadline, true }
@@ -0,0 +1,128 @@
+package relay
+
+import (
+	"sync"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/timechan"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Ticker sends the current time of the wrapping clock on each tick of a base ticker.
+// On an InlineClock, the base ticker is a timer rearmed on each tick by the goroutine moving the clock.
+// Reset replaces the base ticker, so the ticks of the previous run are never relayed.
+type Ticker struct {
+	clock Clock
+
+	// mu protects the fields below, and orders the sends on c with Stop and Reset.
+	mu     sync.Mutex
+	c      chan time.Time
+	ticker pkg.Ticker    // base ticker of the current run, unless the base clock is an InlineClock
+	timer  pkg.Timer     // base timer of the current run, if the base clock is an InlineClock
+	stop   chan struct{} // closed by Stop and Reset to end the current run
+}
+
+func (t *Ticker) Chan() <-chan time.Time { return t.c }
+
+// Stop turns off the ticker.
+// No stale tick is received from the channel after Stop returns.
+func (t *Ticker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.halt()
+}
+
+// Reset stops the ticker and resets its period to the specified duration.
+// No stale tick is received from the channel after Reset returns.
+// The duration must be greater than zero; if not, Reset will panic.
+func (t *Ticker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.halt()
+	t.start(d)
+}
+
+// start starts a new run with a new base ticker. t.mu MUST be held
+// when this method is called.
+func (t *Ticker) start(d time.Duration) {
+	bd, stop := t.clock.baseInterval(d), make(chan struct{})
+	t.stop = stop
+
+	if inline, ok := t.clock.Base.(InlineClock); ok {
+		t.ticker = nil
+		t.timer = inline.InlineFunc(pkg.KindTicker, bd, bd, func() { t.tick(bd, stop) })
+
+		return
+	}
+
+	t.timer = nil
+	t.ticker = t.clock.Base.Ticker(bd)
+
+	go t.run(t.ticker.Chan(), stop)
+}
+
+// halt stops the base ticker, ends the current run and drains the channel.
+// t.mu MUST be held when this method is called.
+func (t *Ticker) halt() {
+	if t.ticker != nil {
+		t.ticker.Stop()
+	} else {
+		t.timer.Stop()
+	}
+
+	select {
+	case <-t.stop:
+	default:
+		close(t.stop)
+	}
+
+	timechan.Drain(t.c)
+}
+
+// run relays the ticks of the base ticker until stop is closed.
+func (t *Ticker) run(ticks <-chan time.Time, stop chan struct{}) {
+	for {
+		select {
+		case <-ticks:
+		case <-stop:
+			return
+		}
+
+		t.mu.Lock()
+		t.send(stop)
+		t.mu.Unlock()
+	}
+}
+
+// tick relays a tick of the base timer and rearms it for the next one, unless stop is closed.
+func (t *Ticker) tick(d time.Duration, stop chan struct{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.send(stop) {
+		t.timer.Reset(d)
+	}
+}
+
+// send sends the current time of the wrapping clock, dropping it if the previous tick has not been
+// received yet. It returns false if stop is closed. t.mu MUST be held when this method is called.
+func (t *Ticker) send(stop chan struct{}) bool {
+	select {
+	case <-stop:
+		return false
+	default:
+	}
+
+	select {
+	case t.c <- t.clock.Now():
+	default:
+	}
+
+	return true
+}
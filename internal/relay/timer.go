@@ -0,0 +1,91 @@
+package relay
+
+import (
+	"sync"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/timechan"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Timer sends the current time of the wrapping clock when it fires.
+type Timer struct {
+	clock Clock
+
+	// mu protects the fields below, and orders the sends on c with Stop and Reset.
+	mu     sync.Mutex
+	c      chan time.Time
+	timer  pkg.Timer // base timer executing send, see Clock.afterFunc
+	gen    uint64    // incremented by Stop and Reset, so the functions of the previous runs do not send
+	active bool      // True if the current run has not sent its value yet
+}
+
+func (t *Timer) Chan() <-chan time.Time { return t.c }
+
+// Stop prevents the timer from firing.
+// It returns true if the call stops the timer, including when its value has not been received yet.
+// No stale value is received from the channel after Stop returns.
+func (t *Timer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.stop()
+}
+
+// Reset changes the expiry time of the timer.
+// It returns the same value as Stop would, and no stale value is received from the channel after Reset returns.
+func (t *Timer) Reset(d time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	active := t.stop()
+	t.start(d)
+
+	return active
+}
+
+// start starts a new run of the timer. Like the timers of the time package, a timer
+// with a duration less than or equal to zero fires immediately. t.mu MUST be held
+// when this method is called.
+func (t *Timer) start(d time.Duration) {
+	gen, bd := t.gen, t.clock.Duration(d)
+
+	t.active = true
+	t.timer = t.clock.afterFunc(pkg.KindTimer, 0, bd, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.send(gen)
+	})
+
+	if bd <= 0 && t.timer.Stop() {
+		t.send(gen)
+	}
+}
+
+// stop stops the base timer and drains the channel. t.mu MUST be held
+// when this method is called.
+func (t *Timer) stop() bool {
+	active := t.active
+
+	t.gen++
+	t.active = false
+	t.timer.Stop()
+
+	return timechan.Drain(t.c) || active
+}
+
+// send sends the current time if the run gen is the current one. t.mu MUST be held
+// when this method is called.
+func (t *Timer) send(gen uint64) {
+	if t.gen != gen || !t.active {
+		return
+	}
+
+	t.active = false
+
+	select {
+	case t.c <- t.clock.Now():
+	default:
+	}
+}
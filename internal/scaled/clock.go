@@ -0,0 +1,81 @@
+// Package scaled implements a clock running faster or slower than a base clock.
+package scaled
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/relay"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Clock reports a virtual time starting at an epoch and moving factor times as fast as a base clock.
+// Timers, tickers, sleeps and contexts wait for the durations of the base clock scaled accordingly.
+type Clock struct {
+	base   pkg.Clock
+	factor float64
+	epoch  time.Time // virtual time when the clock was created
+	start  time.Time // time of the base clock when the clock was created
+	relay  relay.Clock
+}
+
+// NewClock returns a clock starting at epoch and moving factor times as fast as base.
+// The factor must be greater than zero; if not, NewClock will panic.
+func NewClock(base pkg.Clock, factor float64, epoch time.Time) *Clock {
+	if !(factor > 0) || math.IsInf(factor, 1) {
+		panic("non-positive or infinite factor for NewScaled")
+	}
+
+	c := &Clock{base: base, factor: factor, epoch: epoch, start: base.Now()}
+	c.relay = relay.Clock{Base: base, Now: c.Now, Duration: c.baseDuration}
+
+	return c
+}
+
+// baseDuration converts a virtual duration to a duration of the base clock, rounded up
+// so the virtual duration has always elapsed when the base one has.
+func (c *Clock) baseDuration(d time.Duration) time.Duration {
+	bd := math.Ceil(float64(d) / c.factor)
+	if bd >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return time.Duration(bd)
+}
+
+func (c *Clock) After(d time.Duration) <-chan time.Time { return c.Timer(d).Chan() }
+
+func (c *Clock) AfterFunc(d time.Duration, f func()) pkg.Timer { return c.relay.AfterFunc(d, f) }
+
+// Now returns the virtual time, which has the monotonic clock reading of the epoch, if any.
+func (c *Clock) Now() time.Time {
+	elapsed := float64(c.base.Since(c.start)) * c.factor
+	if elapsed >= math.MaxInt64 {
+		elapsed = math.MaxInt64
+	}
+
+	return c.epoch.Add(time.Duration(elapsed))
+}
+
+func (c *Clock) Since(t time.Time) time.Duration { return c.Now().Sub(t) }
+
+func (c *Clock) Until(t time.Time) time.Duration { return t.Sub(c.Now()) }
+
+func (c *Clock) Sleep(d time.Duration) { c.base.Sleep(c.baseDuration(d)) }
+
+func (c *Clock) Tick(d time.Duration) <-chan time.Time { return c.Ticker(d).Chan() }
+
+func (c *Clock) Ticker(d time.Duration) pkg.Ticker { return c.relay.Ticker(d) }
+
+func (c *Clock) Timer(d time.Duration) pkg.Timer { return c.relay.Timer(d) }
+
+func (c *Clock) WithTimeout(parent context.Context, t time.Duration) (context.Context, context.CancelFunc) {
+	return c.WithDeadline(parent, c.Now().Add(t))
+}
+
+// WithDeadline returns a context done when the virtual time reaches the deadline.
+// The Deadline method of the context reports the virtual deadline.
+func (c *Clock) WithDeadline(parent context.Context, d time.Time) (context.Context, context.CancelFunc) {
+	return c.relay.WithDeadline(parent, d)
+}
@@ -0,0 +1,147 @@
+package scaled_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/clocktest"
+	"github.com/itbasis/go-clock/v2/internal/mock"
+	"github.com/itbasis/go-clock/v2/internal/scaled"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+var epoch = time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// Ensure that the virtual time moves factor times as fast as the base clock.
+func TestClock_Now(t *testing.T) {
+	base := mock.NewMock()
+	clock := scaled.NewClock(base, 144, epoch)
+
+	if now := clock.Now(); !now.Equal(epoch) {
+		t.Fatalf("Now() = %v, want %v", now, epoch)
+	}
+
+	base.Add(10 * time.Minute)
+
+	if now := clock.Now(); !now.Equal(epoch.Add(24 * time.Hour)) {
+		t.Fatalf("Now() = %v, want %v", now, epoch.Add(24*time.Hour))
+	}
+
+	if d := clock.Since(epoch); d != 24*time.Hour {
+		t.Fatalf("Since() = %v, want 24h", d)
+	}
+}
+
+// Ensure that the timers wait for the scaled duration and send the virtual time.
+func TestClock_Timer(t *testing.T) {
+	base := mock.NewMock()
+	clock := scaled.NewClock(base, 60, epoch)
+	timer := clock.Timer(time.Hour)
+
+	base.Add(time.Minute - time.Nanosecond)
+	expectNotFired(t, timer.Chan())
+
+	base.Add(time.Nanosecond)
+
+	// The value is sent by the goroutine moving the base clock.
+	select {
+	case now := <-timer.Chan():
+		if !now.Equal(epoch.Add(time.Hour)) {
+			t.Fatalf("timer sent %v, want %v", now, epoch.Add(time.Hour))
+		}
+	default:
+		t.Fatal("timer did not fire")
+	}
+}
+
+// Ensure that the tickers tick with the scaled period and keep their kind on the base clock.
+func TestClock_Ticker(t *testing.T) {
+	base := mock.NewMock()
+	clock := scaled.NewClock(base, 60, epoch)
+	ticker := clock.Ticker(time.Hour)
+	defer ticker.Stop()
+
+	infos := base.PendingTimers()
+	if len(infos) != 1 || infos[0].Kind != pkg.KindTicker || infos[0].Period != time.Minute {
+		t.Fatalf("PendingTimers() = %v, want a ticker of 1m", infos)
+	}
+
+	events := base.AddAndReport(2 * time.Minute).Events
+	if len(events) != 2 || events[0].Kind != pkg.KindTicker {
+		t.Fatalf("AddAndReport() events = %v, want 2 ticks", events)
+	}
+
+	select {
+	case now := <-ticker.Chan():
+		// The second tick is dropped as the first one has not been received.
+		if !now.Equal(epoch.Add(time.Hour)) {
+			t.Fatalf("ticker sent %v, want %v", now, epoch.Add(time.Hour))
+		}
+	default:
+		t.Fatal("ticker did not tick")
+	}
+}
+
+// Ensure that contexts expire with the virtual time and report the virtual deadline.
+func TestClock_WithTimeout(t *testing.T) {
+	base := mock.NewMock()
+	clock := scaled.NewClock(base, 0.5, epoch)
+
+	ctx, cancel := clock.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if d, ok := ctx.Deadline(); !ok || !d.Equal(epoch.Add(time.Minute)) {
+		t.Fatalf("Deadline() = %v, %v; want %v", d, ok, epoch.Add(time.Minute))
+	}
+
+	base.Add(time.Minute)
+
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("Err() = %v before the deadline", err)
+	}
+
+	base.Add(time.Minute)
+
+	if err := ctx.Err(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Err() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+// Ensure that a non-positive factor is rejected.
+func TestNewClock_NonPositiveFactor(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewClock() did not panic")
+		}
+	}()
+
+	scaled.NewClock(mock.NewMock(), 0, epoch)
+}
+
+func TestClock_Conformance(t *testing.T) {
+	const factor = 4
+
+	clocktest.RunConformance(
+		t, func(*testing.T) clocktest.Subject {
+			base := mock.NewMock()
+			s := clocktest.Mock(base)
+			s.Clock = scaled.NewClock(base, factor, epoch)
+			s.Advance = func(d time.Duration) { base.Add((d + factor - 1) / factor) }
+
+			return s
+		},
+	)
+}
+
+// expectNotFired fails the test if a value is ready on the channel.
+func expectNotFired(t *testing.T, ch <-chan time.Time) {
+	t.Helper()
+
+	select {
+	case <-ch:
+		t.Fatal("unexpected value")
+	default:
+	}
+}
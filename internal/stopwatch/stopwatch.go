@@ -0,0 +1,116 @@
+// Package stopwatch implements a stopwatch measuring time on a clock.
+package stopwatch
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// Stopwatch accumulates the time elapsed on a clock while it is running.
+type Stopwatch struct {
+	clock pkg.Clock
+
+	// mu protects all other fields in this struct. The time is read under it,
+	// so concurrent calls see the clock move forward in the order they hold it.
+	mu      sync.Mutex
+	running bool
+	started time.Time       // start of the current run
+	elapsed time.Duration   // total duration of the previous runs
+	lapped  time.Duration   // elapsed time at the end of the previous lap
+	laps    []time.Duration // recorded laps
+}
+
+// NewStopwatch returns a stopped stopwatch measuring time on the clock.
+func NewStopwatch(clock pkg.Clock) *Stopwatch {
+	return &Stopwatch{clock: clock}
+}
+
+// Start starts or resumes the stopwatch. It does nothing if the stopwatch is running.
+func (s *Stopwatch) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+
+	if !s.running {
+		s.running = true
+		s.started = now
+	}
+}
+
+// Stop pauses the stopwatch, keeping the elapsed time. It does nothing if the stopwatch is stopped.
+func (s *Stopwatch) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+
+	if s.running {
+		s.elapsed = s.elapsedAt(now)
+		s.running = false
+	}
+}
+
+// Reset stops the stopwatch and clears the elapsed time and the laps.
+func (s *Stopwatch) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.running = false
+	s.elapsed = 0
+	s.lapped = 0
+	s.laps = nil
+}
+
+// Lap records and returns the time elapsed since the previous lap, or since the stopwatch was reset.
+func (s *Stopwatch) Lap() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+
+	elapsed := s.elapsedAt(now)
+	lap := elapsed - s.lapped
+	s.lapped = elapsed
+	s.laps = append(s.laps, lap)
+
+	return lap
+}
+
+// Elapsed returns the total time elapsed while the stopwatch was running.
+func (s *Stopwatch) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+
+	return s.elapsedAt(now)
+}
+
+// Laps returns the recorded laps in order.
+func (s *Stopwatch) Laps() []time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.laps)
+}
+
+// Running reports whether the stopwatch is running.
+func (s *Stopwatch) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.running
+}
+
+// elapsedAt returns the elapsed time at now. s.mu must be held.
+func (s *Stopwatch) elapsedAt(now time.Time) time.Duration {
+	if !s.running {
+		return s.elapsed
+	}
+
+	return s.elapsed + now.Sub(s.started)
+}
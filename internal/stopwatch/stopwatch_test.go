@@ -0,0 +1,128 @@
+package stopwatch_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/impl"
+	"github.com/itbasis/go-clock/v2/internal/mock"
+	"github.com/itbasis/go-clock/v2/internal/stopwatch"
+)
+
+func expectElapsed(t *testing.T, s *stopwatch.Stopwatch, want time.Duration) {
+	t.Helper()
+
+	if got := s.Elapsed(); got != want {
+		t.Fatalf("Elapsed() = %v, want %v", got, want)
+	}
+}
+
+// Ensure that the stopwatch only accumulates the time elapsed while it is running.
+func TestStopwatch(t *testing.T) {
+	clock := mock.NewMock()
+	s := stopwatch.NewStopwatch(clock)
+
+	clock.Add(time.Second)
+	expectElapsed(t, s, 0)
+
+	s.Start()
+	clock.Add(2 * time.Second)
+	s.Start()
+	expectElapsed(t, s, 2*time.Second)
+
+	s.Stop()
+	clock.Add(time.Hour)
+	s.Stop()
+	expectElapsed(t, s, 2*time.Second)
+
+	if s.Running() {
+		t.Fatal("Running() = true, want false")
+	}
+
+	s.Start()
+	clock.Add(3 * time.Second)
+	expectElapsed(t, s, 5*time.Second)
+
+	s.Reset()
+	expectElapsed(t, s, 0)
+
+	if s.Running() {
+		t.Fatal("Running() after Reset = true, want false")
+	}
+}
+
+// Ensure that the laps measure the running time between them.
+func TestStopwatch_Lap(t *testing.T) {
+	clock := mock.NewMock()
+	s := stopwatch.NewStopwatch(clock)
+
+	s.Start()
+	clock.Add(time.Second)
+
+	if lap := s.Lap(); lap != time.Second {
+		t.Fatalf("Lap() = %v, want %v", lap, time.Second)
+	}
+
+	clock.Add(time.Second)
+	s.Stop()
+	clock.Add(time.Hour)
+	s.Start()
+	clock.Add(time.Second)
+	s.Lap()
+
+	laps := s.Laps()
+	if len(laps) != 2 || laps[0] != time.Second || laps[1] != 2*time.Second {
+		t.Fatalf("Laps() = %v, want [1s 2s]", laps)
+	}
+
+	laps[0] = 0
+	if s.Laps()[0] != time.Second {
+		t.Fatal("Laps() returned the internal slice")
+	}
+
+	s.Reset()
+
+	if laps := s.Laps(); len(laps) != 0 {
+		t.Fatalf("Laps() after Reset = %v, want none", laps)
+	}
+}
+
+// Ensure that the stopwatch measures the real time and is safe for concurrent use.
+func TestStopwatch_Realtime(t *testing.T) {
+	s := stopwatch.NewStopwatch(impl.NewClock())
+	s.Start()
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				s.Lap()
+				s.Elapsed()
+			}
+		}()
+	}
+
+	wg.Wait()
+	time.Sleep(10 * time.Millisecond)
+	s.Stop()
+
+	elapsed := s.Elapsed()
+	if elapsed < 10*time.Millisecond {
+		t.Fatalf("Elapsed() = %v, want at least 10ms", elapsed)
+	}
+
+	var sum time.Duration
+	for _, lap := range s.Laps() {
+		sum += lap
+	}
+
+	if n := len(s.Laps()); n != 400 || sum > elapsed {
+		t.Fatalf("%d laps totaling %v, want 400 laps within %v", n, sum, elapsed)
+	}
+}
@@ -0,0 +1,15 @@
+// Package timechan implements helpers for the channels of timers and tickers.
+package timechan
+
+import "time"
+
+// Drain removes the pending value of a channel, if any.
+// It reports whether a value has been removed.
+func Drain(c chan time.Time) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
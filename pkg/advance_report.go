@@ -0,0 +1,63 @@
+package pkg
+
+import (
+	"fmt"
+	"time"
+)
+
+// Event describes a firing of a timer, a ticker or a context deadline of a mock clock.
+type Event struct {
+	Kind    TimerKind
+	Label   string    // label set with Labeler, if any
+	Time    time.Time // time of the mock clock when the timer fired
+	Dropped bool      // True if the time was not sent because the channel was full
+}
+
+func (e Event) String() string {
+	s := e.Kind.String()
+
+	if e.Label != "" {
+		s += fmt.Sprintf(" %q", e.Label)
+	}
+
+	s += fmt.Sprintf(" at %s", e.Time)
+
+	if e.Dropped {
+		s += " (dropped)"
+	}
+
+	return s
+}
+
+// AdvanceReport lists the events fired while a mock clock moved, in firing order.
+type AdvanceReport struct {
+	From   time.Time // time of the mock clock before the move
+	To     time.Time // time of the mock clock after the move
+	Events []Event
+}
+
+// Fired returns the number of events of the timers with the given label.
+func (r AdvanceReport) Fired(label string) int {
+	n := 0
+
+	for _, e := range r.Events {
+		if e.Label == label {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Dropped returns the number of dropped events of the timers with the given label.
+func (r AdvanceReport) Dropped(label string) int {
+	n := 0
+
+	for _, e := range r.Events {
+		if e.Label == label && e.Dropped {
+			n++
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,43 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBackwardJump is the error a mock clock panics with when Set moves its time backward
+// while the BackwardJumpReject policy is in effect. TrySet returns it instead.
+var ErrBackwardJump = errors.New("backward wall clock jump")
+
+// BackwardJump is the policy of a mock clock for the pending timers when Set moves its time backward.
+type BackwardJump int
+
+const (
+	// BackwardJumpKeep keeps the fire times of the pending timers, so they fire later than
+	// their durations would suggest. This is the default policy.
+	BackwardJumpKeep BackwardJump = iota
+	// BackwardJumpReject makes Set panic with an error wrapping ErrBackwardJump, and TrySet return it.
+	BackwardJumpReject
+	// BackwardJumpWallOnly moves the fire times of the pending timers along with the wall clock,
+	// so they keep their remaining durations like the timers of the runtime, which use the monotonic clock.
+	// It applies to forward jumps too: Set then fires no timer.
+	BackwardJumpWallOnly
+	// BackwardJumpRebase restarts the pending timers and tickers from the new time with their remaining
+	// durations on backward jumps. Unlike BackwardJumpWallOnly, forward jumps fire the due timers.
+	BackwardJumpRebase
+)
+
+func (j BackwardJump) String() string {
+	switch j {
+	case BackwardJumpKeep:
+		return "keep"
+	case BackwardJumpReject:
+		return "reject"
+	case BackwardJumpWallOnly:
+		return "wall only"
+	case BackwardJumpRebase:
+		return "rebase"
+	default:
+		return fmt.Sprintf("BackwardJump(%d)", int(j))
+	}
+}
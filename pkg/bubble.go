@@ -0,0 +1,25 @@
+package pkg
+
+import "time"
+
+// Bubble is a clock following the fake time of a testing/synctest bubble,
+// moved forward programmatically like a Mock. Its methods must be called from within the bubble.
+//
+// It does not implement Mock: the timers of a bubble belong to the runtime, which neither lists them
+// nor reports which goroutines wait on them. So there is no PendingTimers, NextDeadline, Step, BlockUntil
+// or AutoAdvance, and no report of the fired timers; use Wait to let the other goroutines run instead.
+// The fake time has no wall clock jump either, so Monotonic would only be the elapsed time.
+type Bubble interface {
+	Clock
+
+	// Add moves the fake time of the bubble forward by the duration, firing the timers due
+	// on the way, then waits until every other goroutine of the bubble is durably blocked.
+	Add(duration time.Duration)
+	// Set is like Add with the duration until t. It panics with an error wrapping ErrBackwardJump
+	// if t is before the current time, since the fake time of a bubble only moves forward.
+	Set(t time.Time)
+	// TrySet is like Set but returns the error instead of panicking. The fake time is then unchanged.
+	TrySet(t time.Time) error
+	// Wait waits until every other goroutine of the bubble is durably blocked.
+	Wait()
+}
@@ -26,3 +26,28 @@ type Clock interface { //nolint:interfacebloat
 	WithDeadline(parent context.Context, d time.Time) (context.Context, context.CancelFunc)
 	WithTimeout(parent context.Context, t time.Duration) (context.Context, context.CancelFunc)
 }
+
+// OffsetClock is a clock reporting the time of a base clock shifted by an offset,
+// while the durations of its timers, tickers and sleeps are the ones of the base clock.
+type OffsetClock interface {
+	Clock
+
+	// Offset returns the offset from the base clock.
+	Offset() time.Duration
+	// SetOffset changes the offset from the base clock. It is safe for concurrent use.
+	SetOffset(offset time.Duration)
+}
+
+// Freezable is a clock which can be frozen and unfrozen at runtime. While it is frozen,
+// the time is constant and the timers, tickers and context deadlines are held.
+// On unfreeze, the time resumes from where it stopped and the held timers resume with their remaining durations.
+type Freezable interface {
+	Clock
+
+	// Freeze stops the time and holds the timers, until Unfreeze is called.
+	Freeze()
+	// Unfreeze resumes the time and the held timers.
+	Unfreeze()
+	// Frozen reports whether the clock is frozen.
+	Frozen() bool
+}
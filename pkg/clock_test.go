@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/itbasis/go-clock/v2/clocktest"
 	"github.com/itbasis/go-clock/v2/internal/impl"
 	"github.com/itbasis/go-clock/v2/internal/mock"
 )
@@ -241,6 +242,18 @@ func TestClock_Timer_Reset_Unlock(t *testing.T) {
 		}
 	}()
 
-	clock.Add(2 * time.Second)
+	clock.Add(1 * time.Second)
+	// Wait until the timer is reset by the reader.
+	clock.BlockUntil(1)
+	clock.Add(1 * time.Second)
 	wg.Wait()
 }
+
+// Ensure that the real-time clock follows the contract of pkg.Clock.
+func TestClock_Conformance(t *testing.T) {
+	clocktest.RunConformance(
+		t, func(*testing.T) clocktest.Subject {
+			return clocktest.RealTime(impl.NewClock())
+		},
+	)
+}
@@ -0,0 +1,27 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotIdle is wrapped by the errors of a mock clock stopped by a bound before being idle.
+var ErrNotIdle = errors.New("mock clock not idle")
+
+// IdleError reports the bound hit by a mock clock before being idle, and the timers still pending.
+type IdleError struct {
+	Reason  string      // bound hit by the mock clock
+	Pending []TimerInfo // timers pending when the bound was hit, in firing order
+}
+
+func (e *IdleError) Error() string {
+	pending := make([]string, len(e.Pending))
+	for i, p := range e.Pending {
+		pending[i] = p.String()
+	}
+
+	return fmt.Sprintf("%s: %s; pending: %s", ErrNotIdle, e.Reason, strings.Join(pending, ", "))
+}
+
+func (e *IdleError) Unwrap() error { return ErrNotIdle }
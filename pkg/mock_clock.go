@@ -1,12 +1,71 @@
 package pkg
 
-import "time"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
+// ErrNoPendingTimers is returned when a mock clock has no pending timer to fire.
+var ErrNoPendingTimers = errors.New("no pending timers")
+
+// Mock represents a clock that only moves forward programmatically.
+//
+// Timers, tickers and sleeps due at the same instant fire in the order they were created.
+// Resetting a timer or a ticker counts as creating it again, so it fires after the others due at the same instant.
+// Functions passed to AfterFunc each run in their own goroutine, started in firing order,
+// unless the mock clock was created with WithSyncAfterFunc: they are then executed one at a time
+// in firing order by the goroutine moving the clock.
 type Mock interface {
 	Clock
 
 	Add(duration time.Duration)
 	Set(time time.Time)
+	// TrySet is like Set but returns an error wrapping ErrBackwardJump instead of panicking
+	// when the BackwardJumpReject policy rejects the move. The clock is then unchanged.
+	TrySet(time time.Time) error
+
+	// AddAndReport is like Add but returns a report of the timers fired during the move.
+	AddAndReport(duration time.Duration) AdvanceReport
+	// SetAndReport is like Set but returns a report of the timers fired during the move.
+	SetAndReport(time time.Time) AdvanceReport
+
+	// Monotonic returns the monotonic clock reading, which only moves forward with Add, unlike the wall time.
+	Monotonic() time.Duration
+
+	// BlockUntil blocks until exactly n timers, tickers, sleeps and context deadlines are registered
+	// on the clock. It counts registrations, not goroutines: a timer counts from its creation until it
+	// fires or is stopped, and a ticker until it is stopped, whether or not a goroutine waits on them.
+	BlockUntil(n int)
+	// BlockUntilContext is like BlockUntil but returns the context error if the context is done first.
+	BlockUntilContext(ctx context.Context, n int) error
 
+	// PendingTimers returns snapshots of the pending timers, tickers and context deadlines, in firing order.
+	PendingTimers() []TimerInfo
+	// NextDeadline returns the next fire time of the pending timers, or false if no timer is pending.
+	NextDeadline() (time.Time, bool)
+
+	// Step moves the clock forward to the next pending timer and fires it.
+	// It returns the event of the timer, or false if no timer is pending.
+	Step() (Event, bool)
+	// StepN calls Step n times, or until no timer is pending, and returns the fired events.
+	StepN(n int) []Event
+	// RunUntil calls Step until done returns true for a fired event, and returns the fired events.
+	// It returns ErrNoPendingTimers if no timer is pending before done returns true.
+	RunUntil(done func(event Event) bool) ([]Event, error)
+
+	// WaitForAllTimers moves the clock forward, firing the pending timers in chronological order,
+	// until only tickers are pending. It returns the current time.
 	WaitForAllTimers() time.Time
+	// RunUntilIdle is like WaitForAllTimers but stops with an *IdleError when the next timer is due
+	// more than maxVirtual after the current time, or maxEvents timers have fired.
+	// A bound less than or equal to zero is unlimited. It also stops with an *IdleError when
+	// the functions passed to AfterFunc neither return nor wait on the clock in time.
+	RunUntilIdle(maxVirtual time.Duration, maxEvents int) error
+
+	// AutoAdvance moves the clock forward to the next pending timer whenever at least n goroutines may be
+	// parked on the clock and the functions passed to AfterFunc have returned or wait on it, until the context
+	// is done. A ticker whose previous tick has not been received does not count, as no goroutine is parked
+	// on it. With n less than or equal to zero, it returns nil once no goroutine may be parked on the clock.
+	AutoAdvance(ctx context.Context, n int) error
 }
@@ -0,0 +1,22 @@
+package pkg
+
+import "time"
+
+// Stopwatch measures the time elapsed on a clock while it is running, across pauses.
+// It is safe for concurrent use.
+type Stopwatch interface {
+	// Start starts or resumes the stopwatch. It does nothing if the stopwatch is running.
+	Start()
+	// Stop pauses the stopwatch, keeping the elapsed time. It does nothing if the stopwatch is stopped.
+	Stop()
+	// Reset stops the stopwatch and clears the elapsed time and the laps.
+	Reset()
+	// Lap records and returns the time elapsed since the previous lap, or since the stopwatch was reset.
+	Lap() time.Duration
+	// Elapsed returns the total time elapsed while the stopwatch was running.
+	Elapsed() time.Duration
+	// Laps returns the recorded laps in order.
+	Laps() []time.Duration
+	// Running reports whether the stopwatch is running.
+	Running() bool
+}
@@ -1,10 +1,51 @@
 package pkg
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
+// Ticker holds a channel that receives "ticks" at regular intervals, following the semantics
+// of time.Ticker since Go 1.23: no stale tick is received from the channel after Stop or Reset returns.
 type Ticker interface {
 	Chan() <-chan time.Time
 
 	Stop()
+	// Reset stops the ticker and resets its period. The duration must be greater than zero.
 	Reset(duration time.Duration)
 }
+
+// TickerPolicy is the policy of the tickers of a mock clock for ticks due while the previous one
+// has not been received yet.
+type TickerPolicy int
+
+const (
+	// TickerDrop drops the tick if the channel is full, like time.Ticker. This is the default policy.
+	TickerDrop TickerPolicy = iota
+	// TickerBlock blocks the goroutine moving the clock until the tick is received or the ticker is stopped.
+	TickerBlock
+	// TickerQueue queues every tick, and delivers them in order in the background
+	// until they are received or the ticker is stopped.
+	TickerQueue
+)
+
+func (p TickerPolicy) String() string {
+	switch p {
+	case TickerDrop:
+		return "drop"
+	case TickerBlock:
+		return "block"
+	case TickerQueue:
+		return "queue"
+	default:
+		return fmt.Sprintf("TickerPolicy(%d)", int(p))
+	}
+}
+
+// TickCounter is implemented by the tickers of a mock clock to count their ticks.
+type TickCounter interface {
+	// Delivered returns the number of ticks sent on the channel, except the ones discarded by Stop or Reset.
+	Delivered() int
+	// Dropped returns the number of ticks dropped, or discarded by Stop or Reset before being delivered.
+	Dropped() int
+}
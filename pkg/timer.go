@@ -2,9 +2,14 @@ package pkg
 
 import "time"
 
+// Timer represents a single event, following the semantics of time.Timer since Go 1.23:
+// no stale value is received from the channel after Stop or Reset returns.
 type Timer interface {
 	Chan() <-chan time.Time
 
+	// Stop prevents the timer from firing. It returns true if the call stops the timer,
+	// false if the timer has already expired and its value was received, or been stopped.
 	Stop() bool
+	// Reset changes the timer to expire after the duration. It returns the same value as Stop would.
 	Reset(duration time.Duration) bool
 }
@@ -0,0 +1,65 @@
+package pkg
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimerKind describes how a pending timer of a mock clock was created.
+type TimerKind int
+
+const (
+	KindTimer           TimerKind = iota + 1 // created by Timer, After or Sleep
+	KindTicker                               // created by Ticker or Tick
+	KindAfterFunc                            // created by AfterFunc
+	KindContextDeadline                      // created by WithDeadline or WithTimeout
+)
+
+func (k TimerKind) String() string {
+	switch k {
+	case KindTimer:
+		return "timer"
+	case KindTicker:
+		return "ticker"
+	case KindAfterFunc:
+		return "afterfunc"
+	case KindContextDeadline:
+		return "context deadline"
+	default:
+		return fmt.Sprintf("TimerKind(%d)", int(k))
+	}
+}
+
+// TimerInfo is a snapshot of a pending timer of a mock clock.
+type TimerInfo struct {
+	Kind   TimerKind
+	Next   time.Time     // next fire time
+	Period time.Duration // time between ticks, zero for timers
+	Label  string        // label set with Labeler, if any
+	Caller string        // file:line of the code which created the timer, if captured
+	Stack  string        // innermost frames of the stack of the code which created the timer, if captured
+}
+
+func (i TimerInfo) String() string {
+	s := fmt.Sprintf("%s at %s", i.Kind, i.Next)
+
+	if i.Period > 0 {
+		s += fmt.Sprintf(" every %s", i.Period)
+	}
+
+	if i.Label != "" {
+		s += fmt.Sprintf(" %q", i.Label)
+	}
+
+	if i.Caller != "" {
+		s += " created at " + i.Caller
+	}
+
+	return s
+}
+
+// Labeler is implemented by the timers and tickers of a mock clock
+// to label them in TimerInfo snapshots.
+type Labeler interface {
+	SetLabel(label string)
+}
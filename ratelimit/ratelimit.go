@@ -0,0 +1,36 @@
+// Package ratelimit implements rate limiters reading the time from a pkg.Clock,
+// so tests using a mock clock assert exactly when events are limited.
+package ratelimit
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNegativeCount       = errors.New("negative number of events")
+	ErrExceedsBurst        = errors.New("tokens exceed the burst of the bucket")
+	ErrWouldExceedDeadline = errors.New("wait would exceed the context deadline")
+)
+
+// Limiter limits the rate of events.
+type Limiter interface {
+	// Allow reports whether an event may happen now, counting it if so.
+	Allow() bool
+	// AllowN reports whether n events may happen now, counting them if so.
+	// It returns false if n is negative.
+	AllowN(n int) bool
+}
+
+var (
+	_ Limiter = (*TokenBucket)(nil)
+	_ Limiter = (*FixedWindow)(nil)
+	_ Limiter = (*SlidingLog)(nil)
+	_ Limiter = (*SlidingWindow)(nil)
+)
+
+func checkWindow(window time.Duration, name string) {
+	if window <= 0 {
+		panic("non-positive window for ratelimit." + name)
+	}
+}
@@ -0,0 +1,212 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/internal/deadline"
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// TokenBucket is a limiter adding a token to a bucket of burst tokens at every interval.
+// Each event takes a token from the bucket. It is safe for concurrent use.
+type TokenBucket struct {
+	clock pkg.Clock
+	every time.Duration
+	burst int
+
+	mu sync.Mutex
+	// zero is the time at which the bucket was empty, or will be once the pending reservations are honored.
+	zero time.Time
+}
+
+// NewTokenBucket returns a full bucket of burst tokens, adding a token at every interval.
+// It panics if the interval or the burst is not positive.
+func NewTokenBucket(clock pkg.Clock, every time.Duration, burst int) *TokenBucket {
+	if every <= 0 {
+		panic("non-positive interval for ratelimit.NewTokenBucket")
+	}
+
+	if burst <= 0 {
+		panic("non-positive burst for ratelimit.NewTokenBucket")
+	}
+
+	b := &TokenBucket{clock: clock, every: every, burst: burst}
+	b.zero = clock.Now().Add(-b.span(burst))
+
+	return b
+}
+
+// Burst returns the size of the bucket.
+func (b *TokenBucket) Burst() int { return b.burst }
+
+// Every returns the interval at which a token is added to the bucket.
+func (b *TokenBucket) Every() time.Duration { return b.every }
+
+// Tokens returns the number of tokens in the bucket now, which is negative while reservations
+// wait for tokens.
+func (b *TokenBucket) Tokens() float64 {
+	now := b.clock.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return float64(now.Sub(b.zeroAt(now))) / float64(b.every)
+}
+
+// Allow reports whether a token is available now, taking it if so.
+func (b *TokenBucket) Allow() bool { return b.AllowN(1) }
+
+// AllowN reports whether n tokens are available now, taking them if so.
+// It returns false if n is negative.
+func (b *TokenBucket) AllowN(n int) bool {
+	return b.reserve(b.clock.Now(), n, 0).ok
+}
+
+// Reserve reserves a token. See ReserveN.
+func (b *TokenBucket) Reserve() *Reservation { return b.ReserveN(1) }
+
+// ReserveN reserves n tokens, which are taken from the bucket once the reservation is due.
+// The reservation is not OK if n is negative or exceeds the burst of the bucket.
+func (b *TokenBucket) ReserveN(n int) *Reservation {
+	return b.reserve(b.clock.Now(), n, math.MaxInt64)
+}
+
+// Wait waits with the clock for a token. See WaitN.
+func (b *TokenBucket) Wait(ctx context.Context) error { return b.WaitN(ctx, 1) }
+
+// WaitN waits with the clock for n tokens and takes them, using a context with a timeout of the clock
+// so a mock clock reports the wait as a context deadline.
+// It fails without waiting if n is negative or exceeds the burst of the bucket, or if the tokens would not
+// be available before the deadline of the context, when this deadline was set with the clock of the bucket. If the context is done while waiting, the tokens are
+// returned to the bucket and the cause of the context is returned.
+func (b *TokenBucket) WaitN(ctx context.Context, n int) error {
+	if n < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativeCount, n)
+	}
+
+	if n > b.burst {
+		return fmt.Errorf("%w: %d > %d", ErrExceedsBurst, n, b.burst)
+	}
+
+	if ctx.Err() != nil {
+		return context.Cause(ctx) //nolint:wrapcheck
+	}
+
+	now := b.clock.Now()
+	maxWait := time.Duration(math.MaxInt64)
+
+	// The deadline of a context of another clock does not compare with the times of this one:
+	// the wait then only ends when the context is done.
+	if d, ok := deadline.Of(ctx, b.clock); ok {
+		maxWait = d.Sub(now)
+	}
+
+	r := b.reserve(now, n, maxWait)
+	if !r.ok {
+		return fmt.Errorf("%w: %d tokens", ErrWouldExceedDeadline, n)
+	}
+
+	delay := r.at.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	waitCtx, cancel := b.clock.WithTimeout(ctx, delay)
+	defer cancel()
+
+	<-waitCtx.Done()
+
+	// The context may be done at the time of the reservation, which then keeps its tokens.
+	if ctx.Err() != nil && b.clock.Now().Before(r.at) {
+		r.Cancel()
+
+		return context.Cause(ctx) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+func (b *TokenBucket) reserve(now time.Time, n int, maxWait time.Duration) *Reservation {
+	if n < 0 || n > b.burst {
+		return &Reservation{}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	zero := b.zeroAt(now).Add(b.span(n))
+
+	delay := max(zero.Sub(now), 0)
+	if delay > maxWait {
+		return &Reservation{}
+	}
+
+	b.zero = zero
+
+	return &Reservation{ok: true, bucket: b, tokens: n, at: now.Add(delay)}
+}
+
+// zeroAt returns the time at which the bucket was empty, given that it holds at most burst tokens at now.
+func (b *TokenBucket) zeroAt(now time.Time) time.Time {
+	if full := now.Add(-b.span(b.burst)); b.zero.Before(full) {
+		return full
+	}
+
+	return b.zero
+}
+
+// span returns the time needed to add n tokens to the bucket.
+func (b *TokenBucket) span(n int) time.Duration {
+	return time.Duration(n) * b.every
+}
+
+// Reservation holds tokens reserved in a bucket.
+type Reservation struct {
+	ok     bool
+	bucket *TokenBucket
+	tokens int
+	at     time.Time
+	// canceled is protected by the mutex of the bucket.
+	canceled bool
+}
+
+// OK reports whether the tokens were reserved.
+func (r *Reservation) OK() bool { return r.ok }
+
+// Time returns the time at which the reserved tokens are available.
+func (r *Reservation) Time() time.Time { return r.at }
+
+// Delay returns how long to wait with the clock of the bucket before the reserved tokens are available.
+// It returns math.MaxInt64 if the reservation is not OK.
+func (r *Reservation) Delay() time.Duration {
+	if !r.ok {
+		return math.MaxInt64
+	}
+
+	return max(r.bucket.clock.Until(r.at), 0)
+}
+
+// Cancel returns the reserved tokens to the bucket if the reservation is not due yet.
+// The reservations made after this one keep their time.
+func (r *Reservation) Cancel() {
+	if !r.ok {
+		return
+	}
+
+	b := r.bucket
+	now := b.clock.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if r.canceled || !r.at.After(now) {
+		return
+	}
+
+	r.canceled = true
+	b.zero = b.zero.Add(-b.span(r.tokens))
+}
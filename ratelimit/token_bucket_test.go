@@ -0,0 +1,202 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2"
+	"github.com/itbasis/go-clock/v2/clocktest"
+	"github.com/itbasis/go-clock/v2/pkg"
+	"github.com/itbasis/go-clock/v2/ratelimit"
+)
+
+func expectTokens(t *testing.T, b *ratelimit.TokenBucket, want float64) {
+	t.Helper()
+
+	if got := b.Tokens(); got != want {
+		t.Fatalf("Tokens() = %v, want %v", got, want)
+	}
+}
+
+// Ensure that the bucket allows bursts and refills at its rate.
+func TestTokenBucket_Allow(t *testing.T) {
+	mock := clocktest.New(t)
+	b := ratelimit.NewTokenBucket(mock, 100*time.Millisecond, 3)
+
+	for i := range 3 {
+		if !b.Allow() {
+			t.Fatalf("Allow() %d = false, want true", i)
+		}
+	}
+
+	if b.Allow() {
+		t.Fatal("Allow() on an empty bucket = true, want false")
+	}
+
+	mock.Add(50 * time.Millisecond)
+	expectTokens(t, b, 0.5)
+
+	mock.Add(50 * time.Millisecond)
+
+	if !b.Allow() || b.Allow() {
+		t.Fatal("want exactly one token after an interval")
+	}
+
+	mock.Add(time.Hour)
+	expectTokens(t, b, 3)
+
+	if b.AllowN(4) || !b.AllowN(3) {
+		t.Fatal("want AllowN to take at most the burst")
+	}
+}
+
+// Ensure that reservations wait for tokens and return them when canceled.
+func TestTokenBucket_Reserve(t *testing.T) {
+	mock := clocktest.New(t)
+	b := ratelimit.NewTokenBucket(mock, time.Second, 2)
+
+	b.AllowN(2)
+
+	r1, r2 := b.Reserve(), b.Reserve()
+	if !r1.OK() || r1.Delay() != time.Second || r2.Delay() != 2*time.Second {
+		t.Fatalf("delays = %v, %v; want 1s, 2s", r1.Delay(), r2.Delay())
+	}
+
+	expectTokens(t, b, -2)
+
+	r2.Cancel()
+	r2.Cancel()
+	expectTokens(t, b, -1)
+
+	mock.Add(time.Second)
+
+	// A due reservation keeps its tokens.
+	r1.Cancel()
+	expectTokens(t, b, 0)
+
+	if r := b.ReserveN(3); r.OK() {
+		t.Fatal("ReserveN() above the burst is OK, want not OK")
+	}
+}
+
+// Ensure that Wait waits with the clock for the tokens.
+func TestTokenBucket_Wait(t *testing.T) {
+	mock := clocktest.New(t)
+	b := ratelimit.NewTokenBucket(mock, time.Second, 1)
+	b.Allow()
+
+	done := make(chan error)
+
+	go func() { done <- b.Wait(context.Background()) }()
+
+	mock.BlockUntil(1)
+
+	if timers := mock.PendingTimers(); timers[0].Kind != pkg.KindContextDeadline {
+		t.Fatalf("pending timer = %v, want a context deadline", timers[0])
+	}
+
+	mock.Add(999 * time.Millisecond)
+
+	select {
+	case err := <-done:
+		t.Fatalf("Wait() returned %v before the token was available", err)
+	default:
+	}
+
+	mock.Add(time.Millisecond)
+
+	if err := <-done; err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	expectTokens(t, b, 0)
+
+	if err := b.WaitN(context.Background(), 2); !errors.Is(err, ratelimit.ErrExceedsBurst) {
+		t.Fatalf("WaitN() error = %v, want %v", err, ratelimit.ErrExceedsBurst)
+	}
+
+	if err := b.WaitN(context.Background(), -1); !errors.Is(err, ratelimit.ErrNegativeCount) {
+		t.Fatalf("WaitN() error = %v, want %v", err, ratelimit.ErrNegativeCount)
+	}
+
+	if r := b.ReserveN(-1); r.OK() {
+		t.Fatal("ReserveN() of negative tokens is OK, want not OK")
+	}
+
+	expectTokens(t, b, 0)
+}
+
+// Ensure that Wait fails without waiting when the token would come after the deadline,
+// and returns the token when the context is canceled.
+func TestTokenBucket_WaitContext(t *testing.T) {
+	mock := clocktest.New(t)
+	b := ratelimit.NewTokenBucket(mock, time.Second, 1)
+	b.Allow()
+
+	ctx, cancel := mock.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	if err := b.Wait(ctx); !errors.Is(err, ratelimit.ErrWouldExceedDeadline) {
+		t.Fatalf("Wait() error = %v, want %v", err, ratelimit.ErrWouldExceedDeadline)
+	}
+
+	// The deadline of the clock is found from derived contexts.
+	derived, cancelDerived := context.WithCancel(ctx)
+	defer cancelDerived()
+
+	if err := b.Wait(derived); !errors.Is(err, ratelimit.ErrWouldExceedDeadline) {
+		t.Fatalf("Wait() error = %v, want %v", err, ratelimit.ErrWouldExceedDeadline)
+	}
+
+	expectTokens(t, b, 0)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() { done <- b.Wait(ctx) }()
+
+	mock.BlockUntil(2)
+	expectTokens(t, b, -1)
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait() error = %v, want %v", err, context.Canceled)
+	}
+
+	expectTokens(t, b, 0)
+}
+
+// Ensure that the deadline of a context of another clock is not compared with the times of the bucket.
+func TestTokenBucket_WaitOtherClock(t *testing.T) {
+	// The mock clock is a day ahead of the real-time deadline.
+	mock := clocktest.New(t, clock.WithStartTime(time.Now().Add(24*time.Hour)))
+	b := ratelimit.NewTokenBucket(mock, time.Second, 1)
+	b.Allow()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	done := make(chan error)
+
+	go func() { done <- b.Wait(ctx) }()
+
+	mock.BlockUntil(1)
+	mock.Add(time.Second)
+
+	if err := <-done; err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+}
+
+// Ensure that a bucket without tokens is rejected.
+func TestNewTokenBucket_NonPositiveBurst(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewTokenBucket() did not panic")
+		}
+	}()
+
+	ratelimit.NewTokenBucket(clocktest.New(t), time.Second, 0)
+}
@@ -0,0 +1,165 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// FixedWindow is a limiter allowing limit events in each window of time.
+// The windows are aligned on multiples of their duration since the zero time.
+// It is safe for concurrent use.
+type FixedWindow struct {
+	clock  pkg.Clock
+	limit  int
+	window time.Duration
+
+	mu    sync.Mutex
+	start time.Time
+	count int
+}
+
+// NewFixedWindow returns a limiter allowing limit events per window. It panics if the window is not positive.
+func NewFixedWindow(clock pkg.Clock, limit int, window time.Duration) *FixedWindow {
+	checkWindow(window, "NewFixedWindow")
+
+	return &FixedWindow{clock: clock, limit: limit, window: window}
+}
+
+// Allow reports whether an event may happen now, counting it if so.
+func (w *FixedWindow) Allow() bool { return w.AllowN(1) }
+
+// AllowN reports whether n events may happen now, counting them if so.
+// It returns false if n is negative.
+func (w *FixedWindow) AllowN(n int) bool {
+	if n < 0 {
+		return false
+	}
+
+	now := w.clock.Now()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if start := now.Truncate(w.window); !start.Equal(w.start) {
+		w.start, w.count = start, 0
+	}
+
+	if w.count+n > w.limit {
+		return false
+	}
+
+	w.count += n
+
+	return true
+}
+
+// SlidingLog is a limiter allowing limit events in any window of time ending now,
+// logging the time of each allowed event. It is safe for concurrent use.
+type SlidingLog struct {
+	clock  pkg.Clock
+	limit  int
+	window time.Duration
+
+	mu  sync.Mutex
+	log []time.Time
+}
+
+// NewSlidingLog returns a limiter allowing limit events in any window. It panics if the window is not positive.
+func NewSlidingLog(clock pkg.Clock, limit int, window time.Duration) *SlidingLog {
+	checkWindow(window, "NewSlidingLog")
+
+	return &SlidingLog{clock: clock, limit: limit, window: window}
+}
+
+// Allow reports whether an event may happen now, counting it if so.
+func (l *SlidingLog) Allow() bool { return l.AllowN(1) }
+
+// AllowN reports whether n events may happen now, counting them if so.
+// It returns false if n is negative.
+// The events of the log leave the window once it has fully passed them.
+func (l *SlidingLog) AllowN(n int) bool {
+	if n < 0 {
+		return false
+	}
+
+	now := l.clock.Now()
+	since := now.Add(-l.window)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	expired := 0
+	for expired < len(l.log) && !l.log[expired].After(since) {
+		expired++
+	}
+
+	l.log = l.log[expired:]
+
+	if len(l.log)+n > l.limit {
+		return false
+	}
+
+	for range n {
+		l.log = append(l.log, now)
+	}
+
+	return true
+}
+
+// SlidingWindow is a limiter approximating a sliding window with the counts of the current
+// and previous fixed windows, weighting the previous count by the part of the previous window
+// still in the sliding window. It is safe for concurrent use.
+type SlidingWindow struct {
+	clock  pkg.Clock
+	limit  int
+	window time.Duration
+
+	mu          sync.Mutex
+	start       time.Time
+	prev, count int
+}
+
+// NewSlidingWindow returns a limiter allowing about limit events in any window.
+// It panics if the window is not positive.
+func NewSlidingWindow(clock pkg.Clock, limit int, window time.Duration) *SlidingWindow {
+	checkWindow(window, "NewSlidingWindow")
+
+	return &SlidingWindow{clock: clock, limit: limit, window: window}
+}
+
+// Allow reports whether an event may happen now, counting it if so.
+func (w *SlidingWindow) Allow() bool { return w.AllowN(1) }
+
+// AllowN reports whether n events may happen now, counting them if so.
+// It returns false if n is negative.
+func (w *SlidingWindow) AllowN(n int) bool {
+	if n < 0 {
+		return false
+	}
+
+	now := w.clock.Now()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if start := now.Truncate(w.window); !start.Equal(w.start) {
+		if start.Sub(w.start) == w.window {
+			w.prev = w.count
+		} else {
+			w.prev = 0
+		}
+
+		w.start, w.count = start, 0
+	}
+
+	weight := float64(w.window-now.Sub(w.start)) / float64(w.window)
+	if float64(w.prev)*weight+float64(w.count+n) > float64(w.limit) {
+		return false
+	}
+
+	w.count += n
+
+	return true
+}
@@ -0,0 +1,104 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/clocktest"
+	"github.com/itbasis/go-clock/v2/ratelimit"
+)
+
+// expectAllow fails the test if the limiter does not allow exactly the expected events.
+func expectAllow(t *testing.T, l ratelimit.Limiter, want ...bool) {
+	t.Helper()
+
+	for i, w := range want {
+		if got := l.Allow(); got != w {
+			t.Fatalf("Allow() %d = %v, want %v", i, got, w)
+		}
+	}
+}
+
+// Ensure that the fixed window resets its count at the start of each window.
+func TestFixedWindow(t *testing.T) {
+	mock := clocktest.New(t)
+	w := ratelimit.NewFixedWindow(mock, 2, time.Second)
+
+	expectAllow(t, w, true, true, false)
+
+	mock.Add(999 * time.Millisecond)
+	expectAllow(t, w, false)
+
+	mock.Add(time.Millisecond)
+	expectAllow(t, w, true, true, false)
+}
+
+// Ensure that the sliding log allows an event once an older one has left the window.
+func TestSlidingLog(t *testing.T) {
+	mock := clocktest.New(t)
+	l := ratelimit.NewSlidingLog(mock, 2, time.Second)
+
+	expectAllow(t, l, true)
+
+	mock.Add(500 * time.Millisecond)
+	expectAllow(t, l, true, false)
+
+	mock.Add(499 * time.Millisecond)
+	expectAllow(t, l, false)
+
+	mock.Add(time.Millisecond)
+	expectAllow(t, l, true, false)
+
+	if l.AllowN(3) {
+		t.Fatal("AllowN() above the limit = true, want false")
+	}
+}
+
+// Ensure that the sliding window weights the count of the previous window.
+func TestSlidingWindow(t *testing.T) {
+	mock := clocktest.New(t)
+	w := ratelimit.NewSlidingWindow(mock, 10, time.Second)
+
+	if !w.AllowN(10) {
+		t.Fatal("AllowN(10) = false, want true")
+	}
+
+	// A quarter of the current window has passed: 7.5 events are counted from the previous one.
+	mock.Add(1250 * time.Millisecond)
+
+	if !w.AllowN(2) || w.Allow() {
+		t.Fatal("want 2 more events in the sliding window")
+	}
+
+	// At the start of a window, the previous count is fully weighted.
+	mock.Add(750 * time.Millisecond)
+
+	if !w.AllowN(8) || w.Allow() {
+		t.Fatal("want 8 more events in the sliding window")
+	}
+
+	// The previous window is forgotten after a whole window without events.
+	mock.Add(2 * time.Second)
+
+	if !w.AllowN(10) {
+		t.Fatal("AllowN(10) = false, want true")
+	}
+}
+
+// Ensure that the limiters reject negative numbers of events without counting them.
+func TestLimiters_AllowNegative(t *testing.T) {
+	mock := clocktest.New(t)
+
+	for _, l := range []ratelimit.Limiter{
+		ratelimit.NewTokenBucket(mock, time.Second, 1),
+		ratelimit.NewFixedWindow(mock, 1, time.Second),
+		ratelimit.NewSlidingLog(mock, 1, time.Second),
+		ratelimit.NewSlidingWindow(mock, 1, time.Second),
+	} {
+		if l.AllowN(-1) {
+			t.Fatalf("%T AllowN(-1) = true, want false", l)
+		}
+
+		expectAllow(t, l, true, false)
+	}
+}
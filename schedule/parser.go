@@ -0,0 +1,219 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrSyntax is wrapped by the errors returned when parsing an invalid cron expression.
+var ErrSyntax = errors.New("invalid cron expression")
+
+// starBit marks a field set by "*" or "?", for the day of month and day of week matching.
+const starBit = 1 << 63
+
+type bounds struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	seconds = bounds{min: 0, max: 59}
+	minutes = bounds{min: 0, max: 59}
+	hours   = bounds{min: 0, max: 23}
+	doms    = bounds{min: 1, max: 31}
+	months  = bounds{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dows = bounds{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// Parse parses a cron expression in the local time zone. See ParseInLocation.
+func Parse(spec string) (Schedule, error) {
+	return ParseInLocation(spec, time.Local)
+}
+
+// ParseInLocation parses a cron expression, whose fields apply to the wall clock of loc.
+// The expression is one of:
+//
+//   - the five fields "minute hour day-of-month month day-of-week", firing at second 0;
+//   - the six fields "second minute hour day-of-month month day-of-week";
+//   - a descriptor: @yearly (or @annually), @monthly, @weekly, @daily (or @midnight), @hourly;
+//   - "@every <duration>", with a duration accepted by time.ParseDuration.
+//
+// Fields accept "*", "?" for the days, lists "a,b", ranges "a-b" and steps "*/n" or "a-b/n".
+// Months and days of week accept three-letter English names, and Sunday is either 0 or 7.
+// Like cron, if both the day of month and the day of week are restricted, either of them matches.
+// A "CRON_TZ=<zone> " or "TZ=<zone> " prefix sets the time zone of the expression.
+func ParseInLocation(spec string, loc *time.Location) (Schedule, error) {
+	fields := strings.Fields(spec)
+
+	if len(fields) > 0 {
+		if zone, ok := cutPrefix(fields[0], "CRON_TZ=", "TZ="); ok {
+			l, err := time.LoadLocation(zone)
+			if err != nil {
+				return nil, fmt.Errorf("%w %q: %w", ErrSyntax, spec, err)
+			}
+
+			loc, fields = l, fields[1:]
+		}
+	}
+
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "@") {
+		if fields[0] == "@every" && len(fields) == 2 {
+			d, err := time.ParseDuration(fields[1])
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("%w %q: invalid duration %q", ErrSyntax, spec, fields[1])
+			}
+
+			return Every(d), nil
+		}
+
+		descriptor, ok := descriptors[fields[0]]
+		if !ok || len(fields) != 1 {
+			return nil, fmt.Errorf("%w %q: unknown descriptor %q", ErrSyntax, spec, fields[0])
+		}
+
+		fields = strings.Fields(descriptor)
+	}
+
+	switch len(fields) {
+	case 5: //nolint:gomnd // minute hour dom month dow
+		fields = append([]string{"0"}, fields...)
+	case 6: //nolint:gomnd // second minute hour dom month dow
+	default:
+		return nil, fmt.Errorf("%w %q: expected 5 or 6 fields, found %d", ErrSyntax, spec, len(fields))
+	}
+
+	s := &SpecSchedule{Location: loc}
+
+	for i, f := range []struct {
+		bits   *uint64
+		bounds bounds
+	}{
+		{&s.Second, seconds}, {&s.Minute, minutes}, {&s.Hour, hours},
+		{&s.Dom, doms}, {&s.Month, months}, {&s.Dow, dows},
+	} {
+		bits, err := parseField(fields[i], f.bounds)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrSyntax, spec, err)
+		}
+
+		*f.bits = bits
+	}
+
+	// Sunday is either 0 or 7.
+	if has(s.Dow, 7) { //nolint:gomnd
+		s.Dow |= 1
+	}
+
+	return s, nil
+}
+
+// parseField returns the bit set of the values of a comma-separated field.
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+
+	for _, expr := range strings.Split(field, ",") {
+		r, err := parseRange(expr, b)
+		if err != nil {
+			return 0, err
+		}
+
+		bits |= r
+	}
+
+	return bits, nil
+}
+
+// parseRange returns the bit set of the values of "*", "?", "a", "a-b", with an optional "/step".
+func parseRange(expr string, b bounds) (uint64, error) {
+	var (
+		lo, hi = b.min, b.max
+		step   = 1
+		star   uint64
+		err    error
+	)
+
+	rangeExpr, stepExpr, hasStep := strings.Cut(expr, "/")
+
+	switch rangeExpr {
+	case "*", "?":
+		star = starBit
+	default:
+		loExpr, hiExpr, hasHi := strings.Cut(rangeExpr, "-")
+
+		if lo, err = parseValue(loExpr, b); err != nil {
+			return 0, err
+		}
+
+		hi = lo
+
+		if hasHi {
+			if hi, err = parseValue(hiExpr, b); err != nil {
+				return 0, err
+			}
+		} else if hasStep {
+			hi = b.max
+		}
+	}
+
+	if hasStep {
+		if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step %q", expr)
+		}
+
+		star = 0
+	}
+
+	if lo > hi {
+		return 0, fmt.Errorf("invalid range %q", expr)
+	}
+
+	var bits uint64
+	for v := lo; v <= hi; v += step {
+		bits |= 1 << uint(v)
+	}
+
+	return bits | star, nil
+}
+
+// parseValue parses a number or a name within the bounds.
+func parseValue(expr string, b bounds) (int, error) {
+	if v, ok := b.names[strings.ToLower(expr)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(expr)
+	if err != nil || v < b.min || v > b.max {
+		return 0, fmt.Errorf("invalid value %q", expr)
+	}
+
+	return v, nil
+}
+
+// cutPrefix returns s without the first of the prefixes it starts with.
+func cutPrefix(s string, prefixes ...string) (string, bool) {
+	for _, prefix := range prefixes {
+		if after, ok := strings.CutPrefix(s, prefix); ok {
+			return after, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,207 @@
+package schedule
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/itbasis/go-clock/v2/pkg"
+)
+
+// EntryID identifies a job of a Scheduler.
+type EntryID int
+
+// Entry is a snapshot of a job of a Scheduler.
+type Entry struct {
+	ID       EntryID
+	Schedule Schedule
+	Next     time.Time // next fire time, zero if none
+	Prev     time.Time // last fire time, zero if the job has not fired yet
+}
+
+// Scheduler runs jobs on their schedules, waiting for the fire times with a clock.
+//
+// A job runs in the goroutine executing the functions passed to the AfterFunc method of the clock,
+// and its next fire time is armed before it runs. A job runs once for each of its fire times, even when
+// the clock has passed several of them by the time it is armed, unless WithSkipMissed is set.
+// With a mock clock created with WithSyncAfterFunc, every job due is done by the time Add returns,
+// including the jobs due several times during the move. With the other mock clocks, the missed fire times
+// are due by then, and run as the clock moves again, e.g. with RunUntilIdle.
+type Scheduler struct {
+	clock      pkg.Clock
+	skipMissed bool
+
+	// mu protects all other fields in this struct, and the entries.
+	mu      sync.Mutex
+	entries map[EntryID]*entry
+	lastID  EntryID
+	stopped bool
+}
+
+type entry struct {
+	id       EntryID
+	schedule Schedule
+	job      func()
+	next     time.Time
+	prev     time.Time
+	timer    pkg.Timer
+}
+
+// Option configures a Scheduler.
+type Option func(s *Scheduler)
+
+// WithSkipMissed skips the fire times the clock has passed when a job is armed, so a late job runs once
+// for all of them, like after a suspend of the system.
+func WithSkipMissed() Option {
+	return func(s *Scheduler) {
+		s.skipMissed = true
+	}
+}
+
+// NewScheduler returns a scheduler waiting for the fire times of its jobs with the clock.
+func NewScheduler(clock pkg.Clock, opts ...Option) *Scheduler {
+	s := &Scheduler{clock: clock, entries: make(map[EntryID]*entry)}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Add parses the cron expression with Parse and schedules the job on it.
+func (s *Scheduler) Add(spec string, job func()) (EntryID, error) {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Schedule(schedule, job), nil
+}
+
+// Schedule runs the job on the schedule, from the current time of the clock.
+func (s *Scheduler) Schedule(schedule Schedule, job func()) EntryID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+	e := &entry{id: s.lastID, schedule: schedule, job: job}
+	s.entries[e.id] = e
+
+	if !s.stopped {
+		s.arm(e, s.clock.Now())
+	}
+
+	return e.id
+}
+
+// Remove stops and removes a job. It does not wait for a running job to return.
+func (s *Scheduler) Remove(id EntryID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[id]; ok {
+		s.disarm(e)
+		delete(s.entries, id)
+	}
+}
+
+// Entries returns snapshots of the jobs, in the order of their next fire times.
+func (s *Scheduler) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]Entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, Entry{ID: e.id, Schedule: e.schedule, Next: e.next, Prev: e.prev})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Next.IsZero() != b.Next.IsZero() {
+			return b.Next.IsZero()
+		}
+
+		if !a.Next.Equal(b.Next) {
+			return a.Next.Before(b.Next)
+		}
+
+		return a.ID < b.ID
+	})
+
+	return entries
+}
+
+// Stop stops the jobs from firing until Start is called. It does not wait for running jobs to return.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopped = true
+
+	for _, e := range s.entries {
+		s.disarm(e)
+	}
+}
+
+// Start resumes the jobs stopped by Stop, from the current time of the clock.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.stopped {
+		return
+	}
+
+	s.stopped = false
+	now := s.clock.Now()
+
+	for _, e := range s.entries {
+		s.arm(e, now)
+	}
+}
+
+// arm arms the timer of the entry for its next fire time after from, which fires immediately
+// if the clock has passed it. s.mu MUST be held when this method is called.
+func (s *Scheduler) arm(e *entry, from time.Time) {
+	e.next = e.schedule.Next(from)
+	if e.next.IsZero() {
+		return
+	}
+
+	next := e.next
+	e.timer = s.clock.AfterFunc(next.Sub(s.clock.Now()), func() { s.fire(e, next) })
+}
+
+// disarm stops the timer of the entry. s.mu MUST be held when this method is called.
+func (s *Scheduler) disarm(e *entry) {
+	if e.timer != nil {
+		e.timer.Stop()
+		e.timer = nil
+	}
+
+	e.next = time.Time{}
+}
+
+// fire runs the job of the entry due at next, after arming its following fire time.
+func (s *Scheduler) fire(e *entry, next time.Time) {
+	s.mu.Lock()
+
+	if s.stopped || s.entries[e.id] != e || !e.next.Equal(next) {
+		s.mu.Unlock()
+
+		return
+	}
+
+	// The following fire time is the next one after this one, even if the clock has passed it.
+	from := next
+	if now := s.clock.Now(); s.skipMissed && now.After(from) {
+		from = now
+	}
+
+	e.prev = next
+	s.arm(e, from)
+	s.mu.Unlock()
+
+	e.job()
+}
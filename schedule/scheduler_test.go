@@ -0,0 +1,168 @@
+package schedule_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itbasis/go-clock/v2"
+	"github.com/itbasis/go-clock/v2/clocktest"
+	"github.com/itbasis/go-clock/v2/pkg"
+	"github.com/itbasis/go-clock/v2/schedule"
+)
+
+// Ensure that the jobs fire on their schedules as the mock clock moves.
+func TestScheduler(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	mock := clocktest.New(t, clock.WithStartTime(time.Date(2024, time.March, 8, 12, 0, 0, 0, ny)), clock.WithSyncAfterFunc())
+	s := schedule.NewScheduler(mock)
+	defer s.Stop()
+
+	var weekdays, nightly []time.Time
+
+	if _, err := s.Add("CRON_TZ=America/New_York 0 2 * * mon-fri", func() { weekdays = append(weekdays, mock.Now()) }); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Add("CRON_TZ=America/New_York 30 2 * * *", func() { nightly = append(nightly, mock.Now()) }); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.Add(7 * 24 * time.Hour)
+
+	// Friday 8th to Friday 15th: Monday 11th to Friday 15th.
+	if len(weekdays) != 5 {
+		t.Fatalf("weekday job fired at %v, want 5 times", weekdays)
+	}
+
+	for _, fired := range weekdays {
+		if wd := fired.In(ny).Weekday(); wd == time.Saturday || wd == time.Sunday {
+			t.Errorf("weekday job fired on %v", fired.In(ny))
+		}
+	}
+
+	// 02:30 is skipped on March 10th, so the job fires at the DST transition.
+	want := []time.Time{
+		time.Date(2024, time.March, 9, 2, 30, 0, 0, ny),
+		time.Date(2024, time.March, 10, 3, 0, 0, 0, ny),
+		time.Date(2024, time.March, 11, 2, 30, 0, 0, ny),
+	}
+
+	if len(nightly) != 7 {
+		t.Fatalf("nightly job fired at %v, want 7 times", nightly)
+	}
+
+	for i, w := range want {
+		if !nightly[i].Equal(w) {
+			t.Errorf("nightly job fired at %v, want %v", nightly[i], w)
+		}
+	}
+}
+
+// Ensure that removed and stopped jobs do not fire.
+func TestScheduler_RemoveStop(t *testing.T) {
+	mock := clocktest.New(t, clock.WithSyncAfterFunc())
+	s := schedule.NewScheduler(mock)
+
+	var removed, stopped int
+
+	id := s.Schedule(schedule.Every(time.Minute), func() { removed++ })
+	s.Schedule(schedule.Every(time.Minute), func() { stopped++ })
+
+	if entries := s.Entries(); len(entries) != 2 || !entries[0].Next.Equal(mock.Now().Add(time.Minute)) {
+		t.Fatalf("Entries() = %v, want 2 entries due in 1m", entries)
+	}
+
+	mock.Add(2 * time.Minute)
+	s.Remove(id)
+	mock.Add(2 * time.Minute)
+
+	if removed != 2 || stopped != 4 {
+		t.Fatalf("jobs fired %d and %d times, want 2 and 4", removed, stopped)
+	}
+
+	s.Stop()
+	mock.Add(2 * time.Minute)
+
+	if stopped != 4 {
+		t.Fatalf("stopped job fired %d times, want 4", stopped)
+	}
+
+	s.Start()
+	defer s.Stop()
+
+	mock.Add(time.Minute)
+
+	if stopped != 5 {
+		t.Fatalf("restarted job fired %d times, want 5", stopped)
+	}
+
+	if entries := s.Entries(); len(entries) != 1 || !entries[0].Prev.Equal(mock.Now()) {
+		t.Fatalf("Entries() = %v, want 1 entry fired now", entries)
+	}
+}
+
+// Ensure that a job late by several fire times on a mock clock running AfterFunc functions
+// in their own goroutines runs once for each of them.
+func TestScheduler_CatchUp(t *testing.T) {
+	mock := clocktest.New(t)
+	s := schedule.NewScheduler(mock)
+	defer s.Stop()
+
+	var fired atomic.Int32
+
+	start := mock.Now()
+	s.Schedule(schedule.Every(time.Minute), func() { fired.Add(1) })
+
+	// The job is armed again after the move, for fire times the clock has passed.
+	mock.Add(5 * time.Minute)
+
+	var idle *pkg.IdleError
+	if err := mock.RunUntilIdle(time.Second, 0); !errors.As(err, &idle) {
+		t.Fatalf("RunUntilIdle() = %v, want an idle error", err)
+	}
+
+	if n := fired.Load(); n != 5 {
+		t.Fatalf("job fired %d times, want 5", n)
+	}
+
+	if entries := s.Entries(); len(entries) != 1 || !entries[0].Next.Equal(start.Add(6*time.Minute)) {
+		t.Fatalf("Entries() = %v, want 1 entry due at %v", entries, start.Add(6*time.Minute))
+	}
+}
+
+// Ensure that the missed fire times are skipped with WithSkipMissed.
+func TestScheduler_SkipMissed(t *testing.T) {
+	mock := &queuedClock{Mock: clocktest.New(t, clock.WithSyncAfterFunc()), fns: make(chan func(), 1)}
+	s := schedule.NewScheduler(mock, schedule.WithSkipMissed())
+	defer s.Stop()
+
+	var fired int
+
+	start := mock.Now()
+	s.Schedule(schedule.Every(time.Minute), func() { fired++ })
+
+	// The job due after 1m is armed again once the clock has passed 4 more fire times.
+	mock.Add(5 * time.Minute)
+	(<-mock.fns)()
+
+	if fired != 1 {
+		t.Fatalf("job fired %d times, want 1", fired)
+	}
+
+	if entries := s.Entries(); len(entries) != 1 || !entries[0].Next.Equal(start.Add(6*time.Minute)) {
+		t.Fatalf("Entries() = %v, want 1 entry due at %v", entries, start.Add(6*time.Minute))
+	}
+}
+
+// queuedClock queues the functions passed to AfterFunc when they are due, so the test runs them late.
+type queuedClock struct {
+	pkg.Mock
+
+	fns chan func()
+}
+
+func (c *queuedClock) AfterFunc(d time.Duration, f func()) pkg.Timer {
+	return c.Mock.AfterFunc(d, func() { c.fns <- f })
+}
@@ -0,0 +1,176 @@
+// Package schedule runs jobs on cron schedules using a pkg.Clock, so the same job definitions
+// run against the real-time clock in production and against a mock clock in tests.
+package schedule
+
+import (
+	"time"
+)
+
+// Schedule describes the fire times of a job.
+type Schedule interface {
+	// Next returns the next fire time strictly after t, or the zero time if there is none.
+	Next(t time.Time) time.Time
+}
+
+// searchYears is the number of years searched for a fire time before giving up.
+const searchYears = 5
+
+// SpecSchedule is a schedule defined by the fields of a cron expression.
+//
+// The fields apply to the wall clock of Location. A fire time skipped by a daylight saving time
+// transition fires at the transition, and a fire time repeated by a transition fires once, at its first occurrence.
+type SpecSchedule struct {
+	Second, Minute, Hour, Dom, Month, Dow uint64 // bit sets of the allowed values
+
+	Location *time.Location
+}
+
+// Every returns a schedule firing every d, starting d after the time passed to Next.
+// A duration less than one second is rounded up to one second.
+func Every(d time.Duration) Schedule {
+	if d < time.Second {
+		d = time.Second
+	}
+
+	return every{d: d}
+}
+
+type every struct {
+	d time.Duration
+}
+
+func (e every) Next(t time.Time) time.Time { return t.Add(e.d) }
+
+// Next returns the next fire time strictly after t, or the zero time if there is none within five years.
+func (s *SpecSchedule) Next(t time.Time) time.Time {
+	loc := s.Location
+	if loc == nil {
+		loc = time.Local
+	}
+
+	t = t.In(loc)
+
+	for civil := civilTime(t); ; {
+		civil = s.nextCivil(civil)
+		if civil.IsZero() {
+			return time.Time{}
+		}
+
+		if next, ok := s.instant(civil, t, loc); ok {
+			return next
+		}
+	}
+}
+
+// civilTime returns the wall clock of t as a time in UTC, which has no daylight saving time.
+func civilTime(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, minute, sec := t.Clock()
+
+	return time.Date(year, month, day, hour, minute, sec, 0, time.UTC)
+}
+
+// nextCivil returns the next wall clock strictly after civil matching the schedule,
+// or the zero time if there is none within five years.
+func (s *SpecSchedule) nextCivil(civil time.Time) time.Time {
+	t := civil.Add(time.Second)
+	limit := t.Year() + searchYears
+
+wrap:
+	if t.Year() > limit {
+		return time.Time{}
+	}
+
+	for !has(s.Month, int(t.Month())) {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+
+	for !s.dayMatches(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+
+	for !has(s.Hour, t.Hour()) {
+		t = t.Truncate(time.Hour).Add(time.Hour)
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+
+	for !has(s.Minute, t.Minute()) {
+		t = t.Truncate(time.Minute).Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+
+	for !has(s.Second, t.Second()) {
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto wrap
+		}
+	}
+
+	return t
+}
+
+// dayMatches reports whether the day of t matches the schedule. Like cron, if both the day of month
+// and the day of week are restricted, the day matches if either of them matches.
+func (s *SpecSchedule) dayMatches(t time.Time) bool {
+	dom := has(s.Dom, t.Day())
+	dow := has(s.Dow, int(t.Weekday()))
+
+	if s.Dom&starBit != 0 || s.Dow&starBit != 0 {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// instant returns the first instant after the wall clock civil in loc, if it is after t.
+// A wall clock skipped by a transition maps to the transition.
+func (s *SpecSchedule) instant(civil, t time.Time, loc *time.Location) (time.Time, bool) {
+	// Transitions are far apart, so the offsets a day around are the ones before and after a transition.
+	_, before := civil.Add(-24 * time.Hour).In(loc).Zone()
+	_, after := civil.Add(24 * time.Hour).In(loc).Zone()
+
+	var first time.Time
+
+	for _, offset := range []int{before, after} {
+		candidate := civil.Add(-time.Duration(offset) * time.Second).In(loc)
+		if civilTime(candidate).Equal(civil) && (first.IsZero() || candidate.Before(first)) {
+			first = candidate
+		}
+	}
+
+	if first.IsZero() {
+		first = transition(civil.Add(-time.Duration(after)*time.Second), civil.Add(-time.Duration(before)*time.Second), loc)
+	}
+
+	// A repeated wall clock fires at its first occurrence only.
+	return first, first.After(t)
+}
+
+// transition returns the first instant in [lo, hi] with the offset of hi.
+func transition(lo, hi time.Time, loc *time.Location) time.Time {
+	_, offset := hi.In(loc).Zone()
+
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+		if _, o := mid.In(loc).Zone(); o == offset {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	return hi.In(loc)
+}
+
+// has reports whether the bit v is set in bits.
+func has(bits uint64, v int) bool { return bits&(1<<uint(v)) != 0 }
@@ -0,0 +1,130 @@
+package schedule_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata" // DST transitions do not depend on the time zone database of the system
+
+	"github.com/itbasis/go-clock/v2/schedule"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return loc
+}
+
+func TestParse_Next(t *testing.T) {
+	utc := time.UTC
+	from := time.Date(2024, time.March, 1, 12, 30, 15, 0, utc) // a Friday
+
+	for _, tt := range []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.March, 1, 12, 31, 0, 0, utc)},
+		{"* * * * * *", time.Date(2024, time.March, 1, 12, 30, 16, 0, utc)},
+		{"0 2 * * 1-5", time.Date(2024, time.March, 4, 2, 0, 0, 0, utc)},
+		{"0 2 * * mon-fri", time.Date(2024, time.March, 4, 2, 0, 0, 0, utc)},
+		{"*/15 * * * *", time.Date(2024, time.March, 1, 12, 45, 0, 0, utc)},
+		{"30 9 1,15 * *", time.Date(2024, time.March, 15, 9, 30, 0, 0, utc)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, utc)},
+		{"0 0 13 * 5", time.Date(2024, time.March, 8, 0, 0, 0, 0, utc)}, // 13th or Friday
+		{"0 0 * * 7", time.Date(2024, time.March, 3, 0, 0, 0, 0, utc)},  // Sunday
+		{"0 0 1 jan ?", time.Date(2025, time.January, 1, 0, 0, 0, 0, utc)},
+		{"@hourly", time.Date(2024, time.March, 1, 13, 0, 0, 0, utc)},
+		{"@daily", time.Date(2024, time.March, 2, 0, 0, 0, 0, utc)},
+		{"@weekly", time.Date(2024, time.March, 3, 0, 0, 0, 0, utc)},
+		{"@monthly", time.Date(2024, time.April, 1, 0, 0, 0, 0, utc)},
+		{"@yearly", time.Date(2025, time.January, 1, 0, 0, 0, 0, utc)},
+		{"@every 90s", from.Add(90 * time.Second)},
+		{"CRON_TZ=Asia/Tokyo 0 9 * * *", time.Date(2024, time.March, 2, 0, 0, 0, 0, utc)},
+		{"0 0 30 2 *", time.Time{}},
+	} {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := schedule.ParseInLocation(tt.spec, utc)
+			if err != nil {
+				t.Fatalf("ParseInLocation() error = %v", err)
+			}
+
+			if next := s.Next(from); !next.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", next, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"", "* * * *", "* * * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8",
+		"5-1 * * * *", "*/0 * * * *", "a * * * *", "@fortnightly", "@every", "@every -1s", "TZ=Nowhere/Land * * * * *",
+	} {
+		if _, err := schedule.Parse(spec); !errors.Is(err, schedule.ErrSyntax) {
+			t.Errorf("Parse(%q) error = %v, want %v", spec, err, schedule.ErrSyntax)
+		}
+	}
+}
+
+// Ensure that a wall clock skipped by a DST transition fires at the transition.
+func TestSpecSchedule_Next_SpringForward(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+
+	s, err := schedule.ParseInLocation("30 2 * * *", ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2024-03-10 02:00 EST jumps to 03:00 EDT.
+	next := s.Next(time.Date(2024, time.March, 9, 12, 0, 0, 0, ny))
+	if want := time.Date(2024, time.March, 10, 3, 0, 0, 0, ny); !next.Equal(want) {
+		t.Fatalf("Next() = %v, want %v", next, want)
+	}
+
+	next = s.Next(next)
+	if want := time.Date(2024, time.March, 11, 2, 30, 0, 0, ny); !next.Equal(want) {
+		t.Fatalf("Next() = %v, want %v", next, want)
+	}
+}
+
+// Ensure that a wall clock repeated by a DST transition fires once.
+func TestSpecSchedule_Next_FallBack(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+
+	s, err := schedule.ParseInLocation("30 1 * * *", ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2024-11-03 02:00 EDT falls back to 01:00 EST.
+	first := s.Next(time.Date(2024, time.November, 2, 12, 0, 0, 0, ny))
+	if want := time.Date(2024, time.November, 3, 5, 30, 0, 0, time.UTC); !first.Equal(want) {
+		t.Fatalf("Next() = %v, want %v", first, want)
+	}
+
+	next := s.Next(first)
+	if want := time.Date(2024, time.November, 4, 1, 30, 0, 0, ny); !next.Equal(want) {
+		t.Fatalf("Next() = %v, want %v", next, want)
+	}
+
+	// Hourly jobs fire once in the repeated hour too.
+	s, err = schedule.ParseInLocation("0 * * * *", ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2024, time.November, 3, 4, 30, 0, 0, time.UTC) // 00:30 EDT
+	for _, want := range []time.Time{
+		time.Date(2024, time.November, 3, 5, 0, 0, 0, time.UTC), // 01:00 EDT
+		time.Date(2024, time.November, 3, 7, 0, 0, 0, time.UTC), // 02:00 EST
+	} {
+		if from = s.Next(from); !from.Equal(want) {
+			t.Fatalf("Next() = %v, want %v", from, want)
+		}
+	}
+}